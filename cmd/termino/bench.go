@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"termino/internal/bot"
+)
+
+// runBench implements `termino bench`, which plays headless bot games over a
+// range of seeds and reports the averages.
+func runBench(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	games := fs.Int("games", 10, "number of games (seeds 1..N)")
+	pieces := fs.Int("pieces", 1000, "maximum pieces per game")
+	depth := fs.Int("depth", bot.DefaultConfig.Depth, "search depth in pieces")
+	beam := fs.Int("beam", bot.DefaultConfig.Beam, "positions kept per depth")
+	fs.Parse(args)
+
+	b := bot.New(bot.Config{Depth: *depth, Beam: *beam, Weights: bot.DefaultWeights})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "seed\tpieces\tlines\tscore\tlevel\ttop out\t")
+
+	var total bot.Result
+	toppedOut := 0
+	start := time.Now()
+	for seed := int64(1); seed <= int64(*games); seed++ {
+		r := b.Play(seed, *pieces)
+		fmt.Fprintf(w, "%d\t%d\t%d\t%d\t%d\t%v\t\n", r.Seed, r.Pieces, r.Lines, r.Score, r.Level, r.ToppedOut)
+		total.Pieces += r.Pieces
+		total.Lines += r.Lines
+		total.Score += r.Score
+		if r.ToppedOut {
+			toppedOut++
+		}
+	}
+	elapsed := time.Since(start)
+
+	n := float64(max(*games, 1))
+	fmt.Fprintf(w, "avg\t%.1f\t%.1f\t%.0f\t\t%d/%d\t\n", float64(total.Pieces)/n, float64(total.Lines)/n, float64(total.Score)/n, toppedOut, *games)
+	w.Flush()
+
+	if total.Pieces > 0 {
+		fmt.Printf("\n%.1f ms per piece\n", float64(elapsed.Microseconds())/1000/float64(total.Pieces))
+	}
+	return nil
+}
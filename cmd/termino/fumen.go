@@ -0,0 +1,107 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"termino/internal/fumen"
+	"termino/internal/game"
+	"termino/internal/tetromino"
+)
+
+// runFumen implements `termino fumen`, which prints the pages of a fumen as text.
+func runFumen(args []string) error {
+	fs := flag.NewFlagSet("fumen", flag.ExitOnError)
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return errors.New("usage: termino fumen <v115@...>")
+	}
+
+	pages, err := fumen.Decode(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	for i := range pages {
+		printPage(os.Stdout, i+1, &pages[i])
+	}
+	return nil
+}
+
+// printPage writes a page as rows of piece letters, with the active piece in lower case.
+func printPage(w io.Writer, n int, p *fumen.Page) {
+	fmt.Fprintf(w, "Page %d", n)
+	if p.Piece != nil {
+		fmt.Fprintf(w, "  %v %s (%d, %d)", p.Piece.Type, rotationNames[p.Piece.Rotation], p.Piece.X, p.Piece.Y)
+	}
+	fmt.Fprintln(w)
+	if p.Comment != "" {
+		fmt.Fprintf(w, "  %s\n", p.Comment)
+	}
+
+	var active [fumen.FieldHeight + 1][fumen.FieldWidth]bool
+	height := p.Field.Height()
+	if p.Piece != nil && p.Piece.Type != fumen.Empty {
+		for _, c := range p.Piece.Cells() {
+			if c[0] >= 0 && c[0] < fumen.FieldWidth && c[1] >= -1 && c[1] < fumen.FieldHeight {
+				active[c[1]+1][c[0]] = true
+				height = max(height, c[1]+1)
+			}
+		}
+	}
+
+	for y := height - 1; y >= -1; y-- {
+		row := p.Field.Garbage
+		if y >= 0 {
+			row = p.Field.Rows[y]
+		} else if row == ([fumen.FieldWidth]fumen.Piece{}) {
+			break
+		} else {
+			fmt.Fprintln(w, "  ----------")
+		}
+		var sb strings.Builder
+		for x, c := range row {
+			if active[y+1][x] {
+				sb.WriteString(strings.ToLower(p.Piece.Type.String()))
+			} else {
+				sb.WriteString(c.String())
+			}
+		}
+		fmt.Fprintf(w, "  %s\n", sb.String())
+	}
+	fmt.Fprintln(w)
+}
+
+var rotationNames = [4]string{"spawn", "right", "reverse", "left"}
+
+// practiceFromFumen builds a game starting from the field of the given page
+// (1-based). The page's piece becomes the active piece and the pieces of the
+// following pages are dealt before the randomizer takes over.
+func practiceFromFumen(code string, page int) (game.GameState, error) {
+	pages, err := fumen.Decode(code)
+	if err != nil {
+		return game.GameState{}, err
+	}
+	if page < 1 || page > len(pages) {
+		return game.GameState{}, fmt.Errorf("fumen has %d pages, cannot start from page %d", len(pages), page)
+	}
+
+	state := game.NewGameState()
+	pages[page-1].ApplyField(&state)
+
+	var queue []tetromino.Tetromino
+	for _, p := range pages[page-1:] {
+		if p.Piece != nil && p.Piece.Type.Name() != "" {
+			queue = append(queue, tetromino.NewTetromino(p.Piece.Type.Name()))
+		}
+	}
+	if len(queue) > 0 {
+		state.NextQueue = append(queue[1:], state.NextQueue...)
+		state.SpawnPiece(queue[0])
+	}
+	state.UpdateGhost()
+	return state, nil
+}
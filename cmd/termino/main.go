@@ -1,16 +1,285 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"strings"
+	"time"
 
+	"termino/internal/bot"
+	"termino/internal/broadcast"
 	"termino/internal/game"
+	"termino/internal/scores"
+	"termino/internal/tbp"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 func main() {
-	p := tea.NewProgram(game.NewModel(), tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "render":
+			if err := runRender(os.Args[2:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		case "bench":
+			if err := runBench(os.Args[2:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		case "fumen":
+			if err := runFumen(os.Args[2:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		case "host":
+			if err := runHost(os.Args[2:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		case "join":
+			if err := runJoin(os.Args[2:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		case "royale":
+			if err := runRoyale(os.Args[2:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		case "watch":
+			if err := runWatch(os.Args[2:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		case "serve":
+			if err := runServe(os.Args[2:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		case "scores":
+			if err := runScores(os.Args[2:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		case "stats":
+			if err := runStats(os.Args[2:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+	}
+
+	fs := flag.NewFlagSet("termino", flag.ExitOnError)
+	record := fs.String("record", "", "write a replay of the last game to this file on exit")
+	fumenCode := fs.String("fumen", "", "start practice from a fumen (v115) board")
+	fumenPage := fs.Int("page", 1, "fumen page to start from")
+	boardPath := fs.String("board", "", "start from a board in the plain-text format")
+	useBot := fs.Bool("bot", false, "watch the built-in bot play")
+	botDelay := fs.Int("bot-delay", 3, "frames between bot inputs")
+	tbpCommand := fs.String("tbp", "", "watch an external Tetris Bot Protocol bot started by this command")
+	finesse := fs.Bool("finesse", false, "practise finesse on an empty board")
+	versus := fs.Bool("versus", false, "play a two-player match on one keyboard")
+	broadcastAddr := fs.String("broadcast", "", "let spectators watch the game by connecting to this address")
+	cpu := fs.String("cpu", "", "play a match against the bot at this strength: easy, medium, hard or max")
+	modeName := fs.String("mode", "marathon", "game mode: marathon, sprint or ultra")
+	showStats := fs.Bool("stats", false, "show the live stats panel; 's' toggles it during a game")
+	fs.Parse(os.Args[1:])
+
+	prefs, err := loadSettings()
+	if err != nil {
 		log.Fatal(err)
 	}
+
+	// Without options, everything is started from the title menu.
+	if fs.NFlag() == 0 {
+		if err := runMenu(prefs); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if *cpu != "" {
+		v, err := newBotVersus(*cpu, *botDelay)
+		if err != nil {
+			log.Fatalf("-cpu: %v", err)
+		}
+		p := tea.NewProgram(v, tea.WithAltScreen())
+		if _, err := p.Run(); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if *versus {
+		p := tea.NewProgram(game.NewVersus(time.Now().UnixNano()), tea.WithAltScreen())
+		if _, err := p.Run(); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if *finesse {
+		if err := runTrainer(); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	mode, err := game.ParseMode(*modeName)
+	if err != nil {
+		log.Fatalf("-mode: %v", err)
+	}
+
+	model := game.NewModelWithMode(time.Now().UnixNano(), mode)
+	switch {
+	case *boardPath != "":
+		state, err := loadBoardFile(*boardPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		model = game.NewModelFromState(state)
+	case *fumenCode != "":
+		state, err := practiceFromFumen(*fumenCode, *fumenPage)
+		if err != nil {
+			log.Fatal(err)
+		}
+		model = game.NewModelFromState(state)
+	}
+
+	// Games played by hand from a seed are saved on quit and offered again
+	// on the next launch.
+	var slot *saveSlot
+	if model.Replay != nil && *tbpCommand == "" && !*useBot {
+		slot, err = openSaveSlot()
+		if err != nil {
+			log.Fatal(err)
+		}
+		resumed, quit, err := slot.offer()
+		if err != nil {
+			log.Fatal(err)
+		}
+		if quit {
+			return
+		}
+		if resumed != nil {
+			model = *resumed
+		}
+		model.SaveSlot = slot.write
+	}
+
+	model.Hints = bot.New(bot.DefaultConfig)
+	model.ShowStats = *showStats
+	model.UseSettings(prefs.Settings)
+	model.SettingsSaved = prefs.save
+
+	var frontend *tbp.Frontend
+	switch {
+	case *tbpCommand != "":
+		args := strings.Fields(*tbpCommand)
+		if len(args) == 0 {
+			log.Fatal("-tbp: empty command")
+		}
+		opts := tbp.DefaultOptions
+		opts.Stderr = os.Stderr
+		f, err := tbp.Start(opts, args[0], args[1:]...)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		frontend = f
+		model.Bot = f
+		model.BotInterval = *botDelay
+	case *useBot:
+		model.Bot = bot.New(bot.DefaultConfig)
+		model.BotInterval = *botDelay
+	}
+
+	if *broadcastAddr != "" {
+		b, err := broadcast.Listen(*broadcastAddr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer b.Close()
+		model.Publish = func(frame int, state *game.GameState) {
+			b.Publish(broadcast.Capture(frame, state))
+		}
+	}
+
+	// Only games played by hand from a seed go on the high-score tables, into
+	// the statistics history and among the recent replays.
+	var program tea.Model = model
+	var recorder *statsRecorder
+	var shelf *replayShelf
+	if model.Bot == nil && model.Replay != nil {
+		r, err := newStatsRecorder()
+		if err != nil {
+			log.Fatal(err)
+		}
+		recorder = r
+		model.Finished = recorder.record
+
+		shelf, err = openReplayShelf()
+		if err != nil {
+			log.Fatal(err)
+		}
+		model.Recorded = shelf.keep
+
+		play, err := newScoredPlay(model, recorder, shelf)
+		if err != nil {
+			log.Fatal(err)
+		}
+		program = play
+	}
+
+	p := tea.NewProgram(program, tea.WithAltScreen())
+	final, err := p.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if frontend != nil && frontend.Err() != nil {
+		fmt.Fprintln(os.Stderr, frontend.Err())
+	}
+
+	if prefs.err != nil {
+		fmt.Fprintln(os.Stderr, prefs.err)
+	}
+	if slot != nil && slot.err != nil {
+		fmt.Fprintln(os.Stderr, slot.err)
+	}
+	if recorder != nil && recorder.err != nil {
+		fmt.Fprintln(os.Stderr, recorder.err)
+	}
+	if shelf != nil && shelf.err != nil {
+		fmt.Fprintln(os.Stderr, shelf.err)
+	}
+	if play, ok := final.(scores.Play); ok {
+		if play.Err != nil {
+			fmt.Fprintln(os.Stderr, play.Err)
+		}
+		final = play.Model
+	}
+
+	if replay := final.(game.Model).Replay; *record != "" && replay != nil {
+		if err := replay.Save(*record); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// loadBoardFile reads a plain-text board into a fresh game.
+func loadBoardFile(path string) (game.GameState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return game.GameState{}, err
+	}
+	state := game.NewGameState()
+	if err := state.LoadBoard(string(data)); err != nil {
+		return game.GameState{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return state, nil
 }
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"termino/internal/bot"
+	"termino/internal/game"
+	"termino/internal/menu"
+	"termino/internal/scores"
+	"termino/internal/store"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// menuBotDelay is the frames between inputs of bots started from the menu.
+const menuBotDelay = 3
+
+// runMenu shows the title menu, from which every kind of game is started.
+// A saved game the player chooses to continue is shown over the menu.
+func runMenu(prefs *preferences) error {
+	slot, err := openSaveSlot()
+	if err != nil {
+		return err
+	}
+	resumed, quit, err := slot.offer()
+	if err != nil || quit {
+		return err
+	}
+	recorder, err := newStatsRecorder()
+	if err != nil {
+		return err
+	}
+	shelf, err := openReplayShelf()
+	if err != nil {
+		return err
+	}
+
+	// prepare readies a game played by hand, which is saved on quit, has its
+	// statistics, replay and high score recorded, and returns to the menu.
+	prepare := func(m game.Model) (tea.Model, error) {
+		m.Hints = bot.New(bot.DefaultConfig)
+		m.UseSettings(prefs.Settings)
+		m.SettingsSaved = prefs.save
+		m.SaveSlot = slot.write
+		m.Finished = recorder.record
+		m.Recorded = shelf.keep
+		m.QuitHint = "'q': Menu"
+		return newScoredPlay(m, recorder, shelf)
+	}
+
+	var errs []error
+	opts := menu.Options{
+		Play: func(s game.Setup) (tea.Model, error) {
+			return prepare(game.NewModelWithSetup(s))
+		},
+		Practice: func() (tea.Model, error) {
+			_, stats, err := loadTrainerStats()
+			if err != nil {
+				return nil, err
+			}
+			return game.NewTrainer(time.Now().UnixNano(), stats), nil
+		},
+		Versus: func(cpu string) (tea.Model, error) {
+			if cpu == "" {
+				return game.NewVersus(time.Now().UnixNano()), nil
+			}
+			return newBotVersus(cpu, menuBotDelay)
+		},
+		Scores: func() (tea.Model, error) {
+			tables, _, err := loadScores()
+			if err != nil {
+				return nil, err
+			}
+			return scores.NewBoard(tables, game.ModeMarathon), nil
+		},
+		Settings: func() (tea.Model, error) {
+			return game.NewSettingsScreen(prefs.Settings, prefs.save), nil
+		},
+		ReplayDir: shelf.dir,
+	}
+
+	app := menu.New(menu.NewTitle(opts))
+	app.Closed = func(screen tea.Model) {
+		switch s := screen.(type) {
+		case scores.Play:
+			if s.Err != nil {
+				errs = append(errs, s.Err)
+			}
+		case game.Trainer:
+			if err := saveTrainerStats(s.Stats); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if resumed != nil {
+		play, err := prepare(*resumed)
+		if err != nil {
+			return err
+		}
+		app.Push(play)
+	}
+
+	p := tea.NewProgram(app, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		return err
+	}
+
+	for _, err := range append(errs, prefs.err, slot.err, recorder.err, shelf.err) {
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}
+	return nil
+}
+
+// newBotVersus starts a match against the bot at the named strength.
+func newBotVersus(strengthName string, delay int) (game.Versus, error) {
+	strength, ok := bot.StrengthByName(strengthName)
+	if !ok {
+		return game.Versus{}, fmt.Errorf("unknown strength %q", strengthName)
+	}
+	seed := time.Now().UnixNano()
+	pilot := strength.Pilot(seed, delay)
+	name := "BOT (" + strings.ToUpper(strength.Name) + ")"
+	return game.NewBotVersus(seed, pilot, name), nil
+}
+
+// saveTrainerStats writes the finesse trainer's results.
+func saveTrainerStats(stats game.TrainerStats) error {
+	path, err := store.Path(trainerFile)
+	if err != nil {
+		return err
+	}
+	return store.Save(path, stats)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"time"
+
+	"termino/internal/netplay"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// runHost implements `termino host`, which waits for one opponent.
+func runHost(args []string) error {
+	fs := flag.NewFlagSet("host", flag.ExitOnError)
+	addr := fs.String("addr", ":7777", "address to listen on")
+	fs.Parse(args)
+
+	l, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+
+	fmt.Printf("Waiting for an opponent on %s...\n", l.Addr())
+	c, err := netplay.Host(l, time.Now().UnixNano())
+	if err != nil {
+		return err
+	}
+	return playMatch(c)
+}
+
+// runJoin implements `termino join <addr>`.
+func runJoin(args []string) error {
+	fs := flag.NewFlagSet("join", flag.ExitOnError)
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return errors.New("usage: termino join <host:port>")
+	}
+
+	c, err := netplay.Join(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	return playMatch(c)
+}
+
+func playMatch(c *netplay.Conn) error {
+	defer c.Close()
+	p := tea.NewProgram(netplay.NewMatch(c), tea.WithAltScreen())
+	_, err := p.Run()
+	return err
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"termino/internal/game"
+	"termino/internal/raster"
+)
+
+// runRender implements `termino render`, which rasterizes a replay into a PNG
+// still or an animated GIF, or a plain-text board into a PNG still.
+func runRender(args []string) error {
+	fs := flag.NewFlagSet("render", flag.ExitOnError)
+	replayPath := fs.String("replay", "", "replay file to render")
+	boardPath := fs.String("board", "", "plain-text board to render as a still")
+	out := fs.String("o", "termino.png", "output file (.png for a still, .gif for an animation)")
+	frame := fs.Int("frame", -1, "frame to render as a still (default: last frame)")
+	every := fs.Int("every", 2, "for GIFs, keep one of every N frames")
+	fs.Parse(args)
+
+	if *boardPath != "" {
+		state, err := loadBoardFile(*boardPath)
+		if err != nil {
+			return err
+		}
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return raster.WritePNG(f, &state)
+	}
+
+	if *replayPath == "" {
+		return errors.New("render: -replay or -board is required")
+	}
+	replay, err := game.LoadReplay(*replayPath)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(*out), ".gif") {
+		if *every < 1 {
+			*every = 1
+		}
+		// GIF delays are in hundredths of a second; the engine runs at 60 frames per second.
+		delay := max(*every*100/60, 2)
+		var anim raster.Animation
+		replay.Play(func(f int, g *game.GameState) bool {
+			if f%*every == 0 || f == replay.Frames {
+				anim.Add(g, delay)
+			}
+			return true
+		})
+		return anim.Encode(f)
+	}
+
+	if *frame < 0 {
+		*frame = replay.Frames
+	}
+	state := replay.StateAt(*frame)
+	return raster.WritePNG(f, &state)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"termino/internal/game"
+	"termino/internal/store"
+)
+
+// replayDir holds the replays of recent games, in the data directory.
+const replayDir = "replays"
+
+// keptReplays is how many recent games keep their replays. Replays saved
+// from the results screen are kept as well.
+const keptReplays = 20
+
+// savedPrefix starts the names of replays saved from the results screen.
+const savedPrefix = "saved-"
+
+// replayShelf keeps the replays of the most recent games.
+type replayShelf struct {
+	dir string
+	err error // Last error writing a replay, reported on exit
+}
+
+func openReplayShelf() (*replayShelf, error) {
+	dir, err := store.Path(replayDir)
+	if err != nil {
+		return nil, err
+	}
+	return &replayShelf{dir: dir}, nil
+}
+
+// keep saves the replay of a finished game and removes the oldest replays
+// beyond keptReplays.
+func (s *replayShelf) keep(r *game.Replay) {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		s.err = err
+		return
+	}
+	name := time.Now().Format("20060102-150405.000") + ".json"
+	if err := r.Save(filepath.Join(s.dir, name)); err != nil {
+		s.err = err
+		return
+	}
+
+	// Names sort by the time the game ended.
+	names, err := filepath.Glob(filepath.Join(s.dir, "[0-9]*.json"))
+	if err != nil {
+		s.err = err
+		return
+	}
+	slices.Sort(names)
+	for _, old := range names[:max(len(names)-keptReplays, 0)] {
+		if err := os.Remove(old); err != nil {
+			s.err = err
+		}
+	}
+}
+
+// save keeps the replay of a finished game for good and returns its path.
+func (s *replayShelf) save(r *game.Replay) (string, error) {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(s.dir, savedPrefix+time.Now().Format("20060102-150405.000")+".json")
+	return path, r.Save(path)
+}
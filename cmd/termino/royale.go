@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"termino/internal/bot"
+	"termino/internal/royale"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const royaleUsage = "usage: termino royale serve [flags] | termino royale join [-name name] <host:port>"
+
+// runRoyale implements `termino royale`, which hosts or joins a battle royale.
+func runRoyale(args []string) error {
+	if len(args) == 0 {
+		return errors.New(royaleUsage)
+	}
+	switch args[0] {
+	case "serve":
+		return runRoyaleServe(args[1:])
+	case "join":
+		return runRoyaleJoin(args[1:])
+	}
+	return errors.New(royaleUsage)
+}
+
+func runRoyaleServe(args []string) error {
+	fs := flag.NewFlagSet("royale serve", flag.ExitOnError)
+	addr := fs.String("addr", ":7780", "address to listen on")
+	humans := fs.Int("humans", 2, "players to wait for before starting")
+	bots := fs.Int("bots", 0, "bots to add when the match starts")
+	strengthName := fs.String("strength", "medium", "bot strength: easy, medium, hard or max")
+	botDelay := fs.Int("bot-delay", 3, "frames between bot inputs")
+	fs.Parse(args)
+
+	strength, ok := bot.StrengthByName(*strengthName)
+	if !ok {
+		return fmt.Errorf("-strength: unknown strength %q", *strengthName)
+	}
+	if *humans < 1 {
+		return errors.New("-humans: at least one human must join")
+	}
+
+	l, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+
+	fmt.Printf("Waiting for %d player(s) on %s...\n", *humans, l.Addr())
+	return royale.Serve(l, royale.Config{
+		Humans:      *humans,
+		Bots:        *bots,
+		Strength:    strength,
+		BotInterval: *botDelay,
+		Seed:        time.Now().UnixNano(),
+	})
+}
+
+func runRoyaleJoin(args []string) error {
+	fs := flag.NewFlagSet("royale join", flag.ExitOnError)
+	name := fs.String("name", os.Getenv("USER"), "name shown to the other players")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return errors.New(royaleUsage)
+	}
+
+	c, err := royale.Dial(fs.Arg(0), *name)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	p := tea.NewProgram(royale.NewGame(c), tea.WithAltScreen())
+	_, err = p.Run()
+	return err
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"termino/internal/game"
+	"termino/internal/store"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// saveFile holds the game in progress when the player quit, in the data
+// directory.
+const saveFile = "save.json"
+
+// saveSlot keeps a single game in progress between launches.
+type saveSlot struct {
+	path string
+	err  error // Last error writing the slot, reported on exit
+}
+
+func openSaveSlot() (*saveSlot, error) {
+	path, err := store.Path(saveFile)
+	if err != nil {
+		return nil, err
+	}
+	return &saveSlot{path: path}, nil
+}
+
+// write stores the game in progress.
+func (s *saveSlot) write(save game.SavedGame) {
+	if err := store.Save(s.path, save); err != nil {
+		s.err = err
+	}
+}
+
+// offer asks whether to continue the saved game, if there is one, and returns
+// it if so. The slot is emptied once the player answers either way. quit is
+// true if the player quit instead of answering.
+func (s *saveSlot) offer() (resumed *game.Model, quit bool, err error) {
+	if _, err := os.Stat(s.path); errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	var save game.SavedGame
+	err = store.Load(s.path, &save)
+	if errors.Is(err, store.ErrCorrupt) {
+		fmt.Fprintln(os.Stderr, err)
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	m, err := game.NewModelFromSave(save)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v; starting a new game\n", s.path, err)
+		return nil, false, s.clear()
+	}
+
+	p := tea.NewProgram(game.NewContinuePrompt(m), tea.WithAltScreen())
+	final, err := p.Run()
+	if err != nil {
+		return nil, false, err
+	}
+	prompt := final.(game.ContinuePrompt)
+	if !prompt.Answered {
+		return nil, true, nil
+	}
+	if err := s.clear(); err != nil {
+		return nil, false, err
+	}
+	if !prompt.Continue {
+		return nil, false, nil
+	}
+	m.Width, m.Height = prompt.Game.Width, prompt.Game.Height
+	return &m, false, nil
+}
+
+func (s *saveSlot) clear() error {
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
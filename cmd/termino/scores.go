@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"termino/internal/game"
+	"termino/internal/scores"
+	"termino/internal/store"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// loadScores reads the high-score tables from the data directory. A corrupt
+// file is reported and replaced by empty tables.
+func loadScores() (*scores.Tables, string, error) {
+	path, err := scores.Path()
+	if err != nil {
+		return nil, "", err
+	}
+	tables, err := scores.Load(path)
+	if errors.Is(err, store.ErrCorrupt) {
+		fmt.Fprintln(os.Stderr, err)
+	} else if err != nil {
+		return nil, "", err
+	}
+	if tables.LastName == "" {
+		tables.LastName = os.Getenv("USER")
+	}
+	return tables, path, nil
+}
+
+// newScoredPlay wraps m so that its high scores are recorded and its results
+// compared with the games recorded by r. Replays chosen on the results screen
+// are kept by shelf.
+func newScoredPlay(m game.Model, r *statsRecorder, shelf *replayShelf) (scores.Play, error) {
+	tables, path, err := loadScores()
+	if err != nil {
+		return scores.Play{}, err
+	}
+	play := scores.NewPlay(m, tables, path)
+	play.History = r.history
+	play.SaveReplay = shelf.save
+	return play, nil
+}
+
+// runScores implements `termino scores`, which shows the high-score tables.
+func runScores(args []string) error {
+	fs := flag.NewFlagSet("scores", flag.ExitOnError)
+	modeName := fs.String("mode", "marathon", "table to show first: marathon, sprint or ultra")
+	fs.Parse(args)
+	mode, err := game.ParseMode(*modeName)
+	if err != nil {
+		return err
+	}
+
+	tables, _, err := loadScores()
+	if err != nil {
+		return err
+	}
+	p := tea.NewProgram(scores.NewBoard(tables, mode), tea.WithAltScreen())
+	_, err = p.Run()
+	return err
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"termino/internal/server"
+	"termino/internal/store"
+
+	"github.com/charmbracelet/ssh"
+)
+
+// Files kept in the data directory by the SSH server.
+const (
+	leaderboardFile = "leaderboard.json"
+	hostKeyFile     = "ssh_host_ed25519"
+)
+
+// runServe implements `termino serve`, which hosts games over SSH until
+// interrupted.
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("ssh", ":2222", "address to accept SSH connections on")
+	hostKey := fs.String("host-key", "", "host key file, generated if missing (default in the data directory)")
+	fs.Parse(args)
+
+	if *hostKey == "" {
+		path, err := store.Path(hostKeyFile)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return err
+		}
+		*hostKey = path
+	}
+	path, err := store.Path(leaderboardFile)
+	if err != nil {
+		return err
+	}
+	lb, err := server.LoadLeaderboard(path)
+	if errors.Is(err, store.ErrCorrupt) {
+		fmt.Fprintln(os.Stderr, err)
+	} else if err != nil {
+		return err
+	}
+
+	srv, err := server.New(lb).SSH(*addr, *hostKey)
+	if err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- srv.ListenAndServe() }()
+	fmt.Printf("Serving termino over SSH on %s\n", *addr)
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	select {
+	case err := <-done:
+		return err
+	case <-sig:
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil && !errors.Is(err, ssh.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"termino/internal/settings"
+)
+
+// preferences are the settings loaded from the data directory, written back
+// when they are changed in the settings menu.
+type preferences struct {
+	settings.Settings
+	path string
+	err  error // Last error saving the settings, reported on exit
+}
+
+// loadSettings reads the settings file. Problems with its contents are
+// reported and the affected settings keep their defaults.
+func loadSettings() (*preferences, error) {
+	path, err := settings.Path()
+	if err != nil {
+		return nil, err
+	}
+	s, err := settings.Load(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	return &preferences{Settings: s, path: path}, nil
+}
+
+// save writes changed settings to the settings file.
+func (p *preferences) save(s settings.Settings) {
+	p.Settings = s
+	if err := s.Save(p.path); err != nil {
+		p.err = err
+	}
+}
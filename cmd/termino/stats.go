@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"termino/internal/game"
+	"termino/internal/stats"
+	"termino/internal/store"
+)
+
+// statsRecorder appends finished games to the statistics history.
+type statsRecorder struct {
+	path    string
+	history *stats.History
+	err     error // Last error saving the history, reported on exit
+}
+
+func newStatsRecorder() (*statsRecorder, error) {
+	path, err := stats.Path()
+	if err != nil {
+		return nil, err
+	}
+	h, err := stats.Load(path)
+	if errors.Is(err, store.ErrCorrupt) {
+		fmt.Fprintln(os.Stderr, err)
+	} else if err != nil {
+		return nil, err
+	}
+	return &statsRecorder{path: path, history: h}, nil
+}
+
+// record saves the statistics of a finished game. Sprints to other goals
+// than the standard one are left out so that their times stay comparable.
+func (r *statsRecorder) record(g *game.GameState) {
+	if g.Mode == game.ModeSprint && g.Goal() != game.SprintLines {
+		return
+	}
+	r.history.Add(stats.FromGame(g))
+	if err := r.history.Save(r.path); err != nil {
+		r.err = err
+	}
+}
+
+// runStats implements `termino stats`, which prints averages, bests and
+// trends over the last games of each mode.
+func runStats(args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	n := fs.Int("n", 20, "number of recent games per mode to summarise")
+	fs.Parse(args)
+	if *n < 1 {
+		return errors.New("-n must be at least 1")
+	}
+
+	path, err := stats.Path()
+	if err != nil {
+		return err
+	}
+	h, err := stats.Load(path)
+	if err != nil {
+		return err
+	}
+	stats.Report(os.Stdout, h, *n)
+	return nil
+}
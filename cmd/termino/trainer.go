@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"termino/internal/game"
+	"termino/internal/store"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// trainerFile holds the finesse trainer's results in the data directory.
+const trainerFile = "finesse.json"
+
+// runTrainer implements `termino -finesse`. Results are added to the ones
+// saved by earlier sessions and summarised on exit.
+func runTrainer() error {
+	path, stats, err := loadTrainerStats()
+	if err != nil {
+		return err
+	}
+
+	p := tea.NewProgram(game.NewTrainer(time.Now().UnixNano(), stats), tea.WithAltScreen())
+	final, err := p.Run()
+	if err != nil {
+		return err
+	}
+
+	stats = final.(game.Trainer).Stats
+	if err := store.Save(path, stats); err != nil {
+		return err
+	}
+	printTrainerStats(stats)
+	return nil
+}
+
+// loadTrainerStats reads the results of earlier sessions. A corrupt file is
+// reported and the results start over.
+func loadTrainerStats() (string, game.TrainerStats, error) {
+	var stats game.TrainerStats
+	path, err := store.Path(trainerFile)
+	if err != nil {
+		return "", stats, err
+	}
+	if err := store.Load(path, &stats); errors.Is(err, store.ErrCorrupt) {
+		fmt.Fprintln(os.Stderr, err)
+	} else if err != nil {
+		return "", stats, err
+	}
+	return path, stats, nil
+}
+
+// printTrainerStats prints accuracy and speed by piece and by column.
+func printTrainerStats(stats game.TrainerStats) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "piece\tattempts\taccuracy\tavg time\t")
+	names := make([]string, 0, len(stats.Pieces))
+	for name := range stats.Pieces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		r := stats.Pieces[name]
+		fmt.Fprintf(w, "%s\t%d\t%.0f%%\t%dms\t\n", name, r.Attempts, r.Accuracy(), r.AverageTime().Milliseconds())
+	}
+
+	fmt.Fprintln(w, "\t\t\t\t")
+	fmt.Fprintln(w, "column\tattempts\taccuracy\tavg time\t")
+	for i, r := range stats.Columns {
+		if r.Attempts == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "%d\t%d\t%.0f%%\t%dms\t\n", i+1, r.Attempts, r.Accuracy(), r.AverageTime().Milliseconds())
+	}
+	w.Flush()
+}
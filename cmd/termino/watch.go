@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"os/signal"
+
+	"termino/internal/broadcast"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/term"
+)
+
+// runWatch implements `termino watch <addr>`, which spectates a game started
+// with -broadcast.
+func runWatch(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	stream := fs.Bool("stream", false, "write frames to the terminal without reading keys, e.g. for a display or a recording")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return errors.New("usage: termino watch [-stream] <host:port>")
+	}
+
+	s, err := broadcast.Dial(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	if *stream {
+		width, height, err := term.GetSize(os.Stdout.Fd())
+		if err != nil {
+			width, height = 80, 24
+		}
+		// Ctrl+C ends the stream, so the cursor is shown again.
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt)
+		defer signal.Stop(interrupt)
+		go func() {
+			if _, ok := <-interrupt; ok {
+				s.Close()
+			}
+		}()
+		return broadcast.Follow(os.Stdout, s, width, height)
+	}
+
+	p := tea.NewProgram(broadcast.NewWatch(s), tea.WithAltScreen())
+	_, err = p.Run()
+	return err
+}
@@ -0,0 +1,237 @@
+// Package bot implements the built-in AI player: a beam search over the move
+// generator's placements, scored by a weighted board evaluator.
+package bot
+
+import (
+	"math/rand"
+	"slices"
+
+	"termino/internal/game"
+	"termino/internal/tetromino"
+	"termino/pkg/consts"
+)
+
+// Config controls the strength of the bot.
+type Config struct {
+	Depth   int     // Pieces searched, including the current one
+	Beam    int     // Positions kept at each depth
+	Weights Weights // Board evaluator weights
+
+	// Mistakes is the chance of deliberately playing a worse placement from
+	// the first search level instead of the best one. Seed seeds those choices.
+	Mistakes float64
+	Seed     int64
+}
+
+// DefaultConfig is fast enough to play in real time.
+var DefaultConfig = Config{
+	Depth:   3,
+	Beam:    8,
+	Weights: DefaultWeights,
+}
+
+// Strength is a preset for playing against the bot.
+type Strength struct {
+	Name     string
+	PPS      float64 // Pieces per second cap, 0 for no cap
+	Depth    int
+	Mistakes float64
+}
+
+// Strengths lists the presets from weakest to strongest.
+var Strengths = []Strength{
+	{Name: "easy", PPS: 0.5, Depth: 1, Mistakes: 0.3},
+	{Name: "medium", PPS: 1, Depth: 2, Mistakes: 0.1},
+	{Name: "hard", PPS: 2, Depth: 3, Mistakes: 0.02},
+	{Name: "max", Depth: 3},
+}
+
+// StrengthByName returns the preset with the given name.
+func StrengthByName(name string) (Strength, bool) {
+	for _, s := range Strengths {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return Strength{}, false
+}
+
+// Config returns the bot configuration for the preset, seeding its mistakes.
+func (s Strength) Config(seed int64) Config {
+	cfg := DefaultConfig
+	cfg.Depth = s.Depth
+	cfg.Mistakes = s.Mistakes
+	cfg.Seed = seed
+	return cfg
+}
+
+// Pilot returns a pilot that plays at the preset's strength, waiting interval
+// frames between inputs.
+func (s Strength) Pilot(seed int64, interval int) game.Pilot {
+	pilot := game.Pilot{Bot: New(s.Config(seed)), Interval: interval}
+	if s.PPS > 0 {
+		pilot.Pace = int(consts.TickRate / s.PPS)
+	}
+	return pilot
+}
+
+// Bot is a heuristic player. It implements game.Advisor.
+type Bot struct {
+	cfg Config
+	rng *rand.Rand
+}
+
+// New creates a bot with the given configuration.
+func New(cfg Config) *Bot {
+	cfg.Depth = max(cfg.Depth, 1)
+	cfg.Beam = max(cfg.Beam, 1)
+	return &Bot{cfg: cfg, rng: rand.New(rand.NewSource(cfg.Seed))}
+}
+
+// Suggest returns the best move for the current piece, considering the hold
+// piece and the next queue up to the configured depth.
+func (b *Bot) Suggest(g *game.GameState) (game.Suggestion, bool) {
+	if g.GameOver {
+		return game.Suggestion{}, false
+	}
+
+	root := node{
+		board: g.Board,
+		queue: append([]tetromino.Tetromino{g.CurrentPiece}, g.NextQueue...),
+		hold:  g.HoldPiece,
+		combo: g.Combo,
+		b2b:   g.BackToBack,
+	}
+
+	// The first move starts from the live position, later ones from spawn.
+	beam := b.expand(&root, true, g)
+	if len(beam) == 0 {
+		return game.Suggestion{}, false
+	}
+	beam = b.prune(beam)
+	first := beam
+
+	for depth := 1; depth < b.cfg.Depth; depth++ {
+		var next []scored
+		for i := range beam {
+			if len(beam[i].node.queue) == 0 {
+				next = append(next, beam[i])
+				continue
+			}
+			next = append(next, b.expand(&beam[i].node, false, nil)...)
+		}
+		if len(next) == 0 {
+			break
+		}
+		beam = b.prune(next)
+	}
+
+	best := beam[0].node.first
+	if b.cfg.Mistakes > 0 && b.rng.Float64() < b.cfg.Mistakes {
+		return b.mistake(first, best), true
+	}
+	return best, true
+}
+
+// mistake picks a random placement among the best of the first search level,
+// other than best. It returns best when there is no alternative.
+func (b *Bot) mistake(first []scored, best game.Suggestion) game.Suggestion {
+	var others []game.Suggestion
+	for _, s := range first {
+		m := s.node.first
+		if m.Hold != best.Hold || m.Placement.X != best.Placement.X || m.Placement.Y != best.Placement.Y || m.Placement.Rotation != best.Placement.Rotation {
+			others = append(others, m)
+		}
+	}
+	if len(others) == 0 {
+		return best
+	}
+	return others[b.rng.Intn(len(others))]
+}
+
+type scored struct {
+	node  node
+	score float64
+}
+
+// prune keeps the best positions, highest score first.
+func (b *Bot) prune(s []scored) []scored {
+	slices.SortStableFunc(s, func(a, c scored) int {
+		switch {
+		case a.score > c.score:
+			return -1
+		case a.score < c.score:
+			return 1
+		}
+		return 0
+	})
+	return s[:min(len(s), b.cfg.Beam)]
+}
+
+// expand returns every position reachable by placing the next piece of n,
+// with or without holding first. When live is set the first piece starts from
+// the live game's position instead of the spawn position.
+func (b *Bot) expand(n *node, live bool, g *game.GameState) []scored {
+	var out []scored
+
+	try := func(piece tetromino.Tetromino, x, y, rot int, hold bool, queue []tetromino.Tetromino, held *tetromino.Tetromino) {
+		for _, p := range game.GeneratePlacements(&n.board, piece, x, y, rot) {
+			child := node{
+				board: n.board,
+				queue: queue,
+				hold:  held,
+				combo: n.combo,
+				b2b:   n.b2b,
+				first: n.first,
+			}
+			if live {
+				child.first = game.Suggestion{Hold: hold, Placement: p}
+			}
+
+			c := place(&child.board, &piece, p)
+			difficult := c.tspin || c.lines == 4
+			if c.lines > 0 {
+				child.reward = n.reward + b.cfg.Weights.reward(c, n.b2b && difficult, n.combo)
+				child.combo = n.combo + 1
+				child.b2b = difficult
+			} else {
+				child.reward = n.reward
+				child.combo = 0
+			}
+			if toppedOut(&child.board) {
+				continue
+			}
+			out = append(out, scored{node: child, score: child.reward + b.cfg.Weights.evaluate(&child.board)})
+		}
+	}
+
+	current := n.queue[0]
+	rest := n.queue[1:]
+	spawnX, spawnY := consts.BoardWidth/2-2, 20
+
+	if live {
+		try(current, g.CurrentX, g.CurrentY, g.CurrentRotation, false, rest, n.hold)
+		if piece, x, y, ok := g.HoldPreview(); ok && piece.Name != current.Name {
+			if n.hold == nil {
+				try(piece, x, y, 0, true, rest[1:], &current)
+			} else {
+				try(piece, x, y, 0, true, rest, &current)
+			}
+		}
+		return out
+	}
+
+	try(current, spawnX, spawnY, 0, false, rest, n.hold)
+	switch {
+	case n.hold == nil && len(rest) > 0:
+		try(rest[0], spawnX, spawnY, 0, true, rest[1:], &current)
+	case n.hold != nil && n.hold.Name != current.Name:
+		try(*n.hold, spawnX, spawnY-2, 0, true, rest, &current)
+	}
+	return out
+}
+
+// toppedOut reports whether blocks reached the rows a new piece spawns into.
+func toppedOut(board *game.Board) bool {
+	return board[20]|board[21] != 0
+}
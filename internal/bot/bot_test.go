@@ -0,0 +1,82 @@
+package bot
+
+import (
+	"testing"
+
+	"termino/internal/game"
+	"termino/pkg/consts"
+)
+
+func TestPlay_Survives(t *testing.T) {
+	b := New(Config{Depth: 2, Beam: 4, Weights: DefaultWeights})
+	r := b.Play(1, 100)
+
+	if r.ToppedOut {
+		t.Fatalf("Expected the bot to survive 100 pieces, topped out after %d", r.Pieces)
+	}
+	// 100 pieces fill 400 cells, enough for 40 lines.
+	if r.Lines < 35 {
+		t.Errorf("Expected at least 35 lines from 100 pieces, got %d", r.Lines)
+	}
+}
+
+func TestEvaluate_PrefersClean(t *testing.T) {
+	clean := game.NewGameStateWithSeed(1)
+	if err := clean.LoadBoard(`
+GGGGGGGGG.
+GGGGGGGGG.`); err != nil {
+		t.Fatal(err)
+	}
+	holey := game.NewGameStateWithSeed(1)
+	if err := holey.LoadBoard(`
+GGGGGGGGG.
+GGGG.GGGG.`); err != nil {
+		t.Fatal(err)
+	}
+
+	w := DefaultWeights
+	if w.evaluate(&clean.Board) <= w.evaluate(&holey.Board) {
+		t.Errorf("Expected a board without holes to score higher")
+	}
+}
+
+func TestTSlots(t *testing.T) {
+	state := game.NewGameStateWithSeed(1)
+	if err := state.LoadBoard(`
+GG........
+G...GGGGGG
+GG.GGGGGGG`); err != nil {
+		t.Fatal(err)
+	}
+
+	var heights [consts.BoardWidth]int
+	for x := range consts.BoardWidth {
+		for row := range consts.BoardHeight {
+			if state.Board[row]&(1<<x) != 0 {
+				heights[x] = consts.BoardHeight - row
+				break
+			}
+		}
+	}
+	if n := tSlots(&state.Board, &heights); n != 1 {
+		t.Errorf("Expected 1 T-spin slot, got %d", n)
+	}
+}
+
+func TestMistakes_PlayWorseMoves(t *testing.T) {
+	state := game.NewGameStateWithSeed(1)
+	best, _ := New(DefaultConfig).Suggest(&state)
+
+	cfg := DefaultConfig
+	cfg.Mistakes = 1
+	sloppy := New(cfg)
+	for range 10 {
+		s, ok := sloppy.Suggest(&state)
+		if !ok {
+			t.Fatal("Expected a suggestion")
+		}
+		if s.Hold == best.Hold && s.Placement.X == best.Placement.X && s.Placement.Rotation == best.Placement.Rotation {
+			t.Fatalf("Expected a mistake every time, got the best move %+v", s.Placement)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package bot
+
+import (
+	"math/bits"
+
+	"termino/internal/game"
+	"termino/internal/tetromino"
+	"termino/pkg/consts"
+)
+
+// fullRow has a bit set for every column of a row.
+const fullRow = tetromino.Bitmask(1<<consts.BoardWidth - 1)
+
+// Weights tune the board evaluator. Penalties are negative.
+type Weights struct {
+	Height    float64 // Per cell of aggregate column height
+	Danger    float64 // Per row of the tallest column above half the visible board
+	Holes     float64 // Per empty cell with a block above it
+	Covered   float64 // Per block stacked above a hole
+	Bumpiness float64 // Per row of height difference between neighbouring columns
+	Wells     float64 // Per row of well depth, excluding the deepest well
+	TSlots    float64 // Per T-spin double slot ready for a T
+
+	// Clear rewards, added once per placement.
+	Single, Double, Triple, Tetris        float64
+	TSpinSingle, TSpinDouble, TSpinTriple float64
+	PerfectClear                          float64
+	B2B                                   float64 // Bonus for clears that continue back-to-back
+	Combo                                 float64 // Per combo step
+}
+
+// DefaultWeights favour clean stacking, tetrises and T-spin doubles.
+var DefaultWeights = Weights{
+	Height:    -0.08,
+	Danger:    -1.5,
+	Holes:     -5.0,
+	Covered:   -0.4,
+	Bumpiness: -0.35,
+	Wells:     -0.5,
+	TSlots:    2.0,
+
+	Single:       -2.0,
+	Double:       -1.5,
+	Triple:       -0.5,
+	Tetris:       6.0,
+	TSpinSingle:  1.0,
+	TSpinDouble:  8.0,
+	TSpinTriple:  12.0,
+	PerfectClear: 20.0,
+	B2B:          2.0,
+	Combo:        0.5,
+}
+
+// evaluate scores a board; higher is better.
+func (w *Weights) evaluate(board *game.Board) float64 {
+	var heights [consts.BoardWidth]int
+	for x := range consts.BoardWidth {
+		bit := tetromino.Bitmask(1 << x)
+		for row := range consts.BoardHeight {
+			if board[row]&bit != 0 {
+				heights[x] = consts.BoardHeight - row
+				break
+			}
+		}
+	}
+
+	score := 0.0
+	maxHeight := 0
+	for x, h := range heights {
+		score += w.Height * float64(h)
+		maxHeight = max(maxHeight, h)
+		if x > 0 {
+			score += w.Bumpiness * float64(abs(h-heights[x-1]))
+		}
+	}
+	if limit := consts.VisibleHeight / 2; maxHeight > limit {
+		score += w.Danger * float64(maxHeight-limit)
+	}
+
+	// Holes are empty cells below the top of their column; covered counts the
+	// blocks sitting above the highest hole of each column.
+	holes, covered := 0, 0
+	var above tetromino.Bitmask
+	for row := consts.BoardHeight - maxHeight; row < consts.BoardHeight; row++ {
+		rowHoles := above &^ board[row] & fullRow
+		holes += bits.OnesCount16(uint16(rowHoles))
+		above |= board[row]
+	}
+	for x, h := range heights {
+		bit := tetromino.Bitmask(1 << x)
+		for row := consts.BoardHeight - h; row < consts.BoardHeight; row++ {
+			if board[row]&bit == 0 {
+				covered += row - (consts.BoardHeight - h)
+				break
+			}
+		}
+	}
+	// A T-spin slot's overhang leaves one cell that looks like a hole but is
+	// filled by the T, so it is not penalised.
+	slots := tSlots(board, &heights)
+	holes = max(holes-slots, 0)
+	score += w.Holes*float64(holes) + w.Covered*float64(covered)
+	score += w.TSlots * float64(slots)
+
+	// Wells: columns lower than both neighbours (walls count as tall).
+	deepest, total := 0, 0
+	for x, h := range heights {
+		left, right := consts.BoardHeight, consts.BoardHeight
+		if x > 0 {
+			left = heights[x-1]
+		}
+		if x < consts.BoardWidth-1 {
+			right = heights[x+1]
+		}
+		if depth := min(left, right) - h; depth > 0 {
+			total += depth
+			deepest = max(deepest, depth)
+		}
+	}
+	score += w.Wells * float64(total-deepest)
+	return score
+}
+
+// tSlots counts T-spin double slots: a three-wide gap with an overhang on one
+// side and a single-cell notch below its centre.
+//
+//	#..     ..#
+//	...  or ...
+//	#.#     #.#
+func tSlots(board *game.Board, heights *[consts.BoardWidth]int) int {
+	filled := func(x, row int) bool {
+		if x < 0 || x >= consts.BoardWidth || row >= consts.BoardHeight {
+			return true
+		}
+		return row >= 0 && board[row]&tetromino.Bitmask(1<<x) != 0
+	}
+
+	count := 0
+	for x := 1; x < consts.BoardWidth-1; x++ {
+		row := consts.BoardHeight - heights[x] - 1 // Notch: the empty cell on top of column x
+		if row < 2 || !filled(x-1, row) || !filled(x+1, row) {
+			continue
+		}
+		mid, top := row-1, row-2
+		if filled(x-1, mid) || filled(x, mid) || filled(x+1, mid) || filled(x, top) {
+			continue
+		}
+		if filled(x-1, top) != filled(x+1, top) {
+			count++
+		}
+	}
+	return count
+}
+
+// reward scores the lines cleared by a placement.
+func (w *Weights) reward(c clear, b2b bool, combo int) float64 {
+	if c.lines == 0 {
+		return 0
+	}
+
+	r := 0.0
+	switch {
+	case c.tspin && c.lines == 1:
+		r = w.TSpinSingle
+	case c.tspin && c.lines == 2:
+		r = w.TSpinDouble
+	case c.tspin:
+		r = w.TSpinTriple
+	case c.lines == 1:
+		r = w.Single
+	case c.lines == 2:
+		r = w.Double
+	case c.lines == 3:
+		r = w.Triple
+	default:
+		r = w.Tetris
+	}
+	if b2b && (c.tspin || c.lines == 4) {
+		r += w.B2B
+	}
+	if c.pc {
+		r += w.PerfectClear
+	}
+	return r + w.Combo*float64(combo)
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,40 @@
+package bot
+
+import (
+	"termino/internal/game"
+)
+
+// Result summarises a headless game.
+type Result struct {
+	Seed      int64
+	Pieces    int
+	Lines     int
+	Score     int
+	Level     int
+	ToppedOut bool
+}
+
+// Play runs a headless game from seed, placing at most maxPieces pieces. The
+// bot drives the game through the same actions a human would press; gravity
+// is not simulated.
+func (b *Bot) Play(seed int64, maxPieces int) Result {
+	state := game.NewGameStateWithSeed(seed)
+	res := Result{Seed: seed}
+
+	for res.Pieces < maxPieces && !state.GameOver {
+		s, ok := b.Suggest(&state)
+		if !ok {
+			break
+		}
+		for _, a := range s.Inputs() {
+			state.Apply(a)
+		}
+		res.Pieces++
+	}
+
+	res.Lines = state.LinesCleared
+	res.Score = state.Score
+	res.Level = state.Level
+	res.ToppedOut = state.GameOver
+	return res
+}
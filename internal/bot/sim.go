@@ -0,0 +1,33 @@
+package bot
+
+import (
+	"termino/internal/game"
+	"termino/internal/tetromino"
+	"termino/pkg/consts"
+)
+
+// node is a simulated position reached during the search.
+type node struct {
+	board  game.Board
+	queue  []tetromino.Tetromino // Pieces still to come, current piece first
+	hold   *tetromino.Tetromino
+	combo  int
+	b2b    bool
+	reward float64 // Sum of clear rewards along the path
+	first  game.Suggestion
+}
+
+// clear describes the lines cleared by a placement.
+type clear struct {
+	lines int
+	tspin bool
+	pc    bool // Perfect clear
+}
+
+// place locks piece at p on board and clears full rows.
+func place(board *game.Board, piece *tetromino.Tetromino, p game.Placement) clear {
+	c := clear{tspin: piece.Name == "T" && p.Spin}
+	c.lines = game.PlaceOnBoard(board, piece, p)
+	c.pc = c.lines > 0 && board[consts.BoardHeight-1] == 0
+	return c
+}
@@ -0,0 +1,168 @@
+// Package broadcast publishes a running game to spectators over TCP and
+// provides the spectator client.
+//
+// The stream starts with a header line, "TRMW <version>", followed by one
+// JSON-encoded Snapshot per line. A spectator that joins mid-game receives the
+// latest snapshot first, so it never waits for the next change to draw.
+package broadcast
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+)
+
+// Version is the stream format version, sent in the header.
+const Version = 1
+
+const magic = "TRMW"
+
+// ErrVersion is returned by Dial when the publisher uses another version.
+var ErrVersion = errors.New("broadcast: incompatible stream version")
+
+// queueSize is the number of snapshots buffered for a spectator. A spectator
+// that falls further behind skips snapshots until it catches up.
+const queueSize = 16
+
+// Broadcaster sends the snapshots of a game to every connected spectator.
+type Broadcaster struct {
+	l net.Listener
+
+	mu         sync.Mutex
+	spectators map[*spectator]struct{}
+	last       []byte   // Latest encoded snapshot, sent to spectators as they join
+	lastSnap   Snapshot // Latest snapshot, to skip unchanged ones
+	closed     bool
+}
+
+type spectator struct {
+	conn  net.Conn
+	queue chan []byte
+}
+
+// Listen starts accepting spectators on addr.
+func Listen(addr string) (*Broadcaster, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	b := &Broadcaster{l: l, spectators: map[*spectator]struct{}{}}
+	go b.accept()
+	return b, nil
+}
+
+// Addr returns the address spectators connect to.
+func (b *Broadcaster) Addr() net.Addr {
+	return b.l.Addr()
+}
+
+// Spectators returns the number of connected spectators.
+func (b *Broadcaster) Spectators() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.spectators)
+}
+
+func (b *Broadcaster) accept() {
+	for {
+		conn, err := b.l.Accept()
+		if err != nil {
+			return
+		}
+
+		s := &spectator{conn: conn, queue: make(chan []byte, queueSize)}
+		b.mu.Lock()
+		if b.closed {
+			b.mu.Unlock()
+			conn.Close()
+			return
+		}
+		if b.last != nil {
+			s.queue <- b.last
+		}
+		b.spectators[s] = struct{}{}
+		b.mu.Unlock()
+
+		go b.serve(s)
+	}
+}
+
+// serve writes queued snapshots to a spectator until either side closes.
+func (b *Broadcaster) serve(s *spectator) {
+	defer func() {
+		b.mu.Lock()
+		delete(b.spectators, s)
+		b.mu.Unlock()
+		s.conn.Close()
+	}()
+
+	w := bufio.NewWriter(s.conn)
+	if _, err := fmt.Fprintf(w, "%s %d\n", magic, Version); err != nil {
+		return
+	}
+	for line := range s.queue {
+		if _, err := w.Write(line); err != nil {
+			return
+		}
+		// Write everything that queued up meanwhile before flushing.
+		if len(s.queue) == 0 {
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	}
+	w.Flush()
+}
+
+// Publish sends a snapshot to all spectators. Snapshots that differ from the
+// previous one only in the frame are not sent.
+func (b *Broadcaster) Publish(snap Snapshot) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	unchanged := snap
+	unchanged.Frame = b.lastSnap.Frame
+	if b.closed || (b.last != nil && unchanged == b.lastSnap) {
+		return
+	}
+	line, err := json.Marshal(snap)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+	b.last, b.lastSnap = line, snap
+
+	for s := range b.spectators {
+		select {
+		case s.queue <- line:
+		default:
+			// Drop the oldest snapshot so the spectator still ends up on
+			// the latest one.
+			select {
+			case <-s.queue:
+			default:
+			}
+			select {
+			case s.queue <- line:
+			default:
+			}
+		}
+	}
+}
+
+// Close disconnects all spectators and stops accepting new ones.
+func (b *Broadcaster) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	for s := range b.spectators {
+		close(s.queue)
+	}
+	return b.l.Close()
+}
@@ -0,0 +1,248 @@
+package broadcast
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"termino/internal/game"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func playedGame(t *testing.T) game.GameState {
+	t.Helper()
+	g := game.NewGameStateWithSeed(7)
+	if err := g.LoadBoard(`
+hold: T
+GGGG..GGGG
+GGGGG.GGGG`); err != nil {
+		t.Fatal(err)
+	}
+	g.Apply(game.ActionLeft)
+	g.Score = 1200
+	g.LinesCleared = 9
+	g.ReceiveGarbage(3)
+	return g
+}
+
+func TestSnapshot_RoundTrip(t *testing.T) {
+	g := playedGame(t)
+	snap := Capture(42, &g)
+
+	state, err := snap.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := state.FormatBoard(), g.FormatBoard(); got != want {
+		t.Errorf("board after round trip:\n%s\nwant:\n%s", got, want)
+	}
+	if state.Score != 1200 || state.LinesCleared != 9 || state.PendingLines() != 3 {
+		t.Errorf("score %d, lines %d, pending %d, want 1200, 9 and 3", state.Score, state.LinesCleared, state.PendingLines())
+	}
+	if state.GhostY != g.GhostY {
+		t.Errorf("ghost at %d, want %d", state.GhostY, g.GhostY)
+	}
+}
+
+func TestSnapshot_RoundTripSprint(t *testing.T) {
+	g := playedGame(t)
+	g.Mode, g.LineGoal = game.ModeSprint, 20
+	g.Elapsed = 83*time.Second + 450*time.Millisecond
+
+	state, err := Capture(42, &g).State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Mode != game.ModeSprint || state.Goal() != 20 || state.Elapsed != g.Elapsed {
+		t.Errorf("mode %q, goal %d, elapsed %v, want sprint, 20 and %v", state.Mode, state.Goal(), state.Elapsed, g.Elapsed)
+	}
+	if view := ansi.Strip(game.RenderGame(&state, nil, 80, 24)); !strings.Contains(view, "Lns: 9/20") || !strings.Contains(view, game.FormatTime(g.Elapsed)) {
+		t.Errorf("Expected the spectator to see the sprint's progress and time:\n%s", view)
+	}
+}
+
+func next(t *testing.T, s *Stream) Snapshot {
+	t.Helper()
+	select {
+	case snap, ok := <-s.incoming:
+		if !ok {
+			t.Fatalf("stream closed: %v", s.err)
+		}
+		return snap
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a snapshot")
+	}
+	return Snapshot{}
+}
+
+func waitSpectators(t *testing.T, b *Broadcaster, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for b.Spectators() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d spectators, want %d", b.Spectators(), n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestBroadcast_SpectatorsJoinMidGame(t *testing.T) {
+	b, err := Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	g := game.NewGameStateWithSeed(1)
+	b.Publish(Capture(1, &g))
+
+	first, err := Dial(b.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.Close()
+	if snap := next(t, first); snap.Frame != 1 {
+		t.Errorf("first spectator joined at frame %d, want 1", snap.Frame)
+	}
+	waitSpectators(t, b, 1)
+
+	// A snapshot that only moves the frame on is not sent.
+	b.Publish(Capture(2, &g))
+	g.Apply(game.ActionHardDrop)
+	b.Publish(Capture(3, &g))
+	if snap := next(t, first); snap.Frame != 3 {
+		t.Errorf("first spectator received frame %d, want 3", snap.Frame)
+	}
+
+	second, err := Dial(b.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer second.Close()
+	snap := next(t, second)
+	if snap.Frame != 3 {
+		t.Errorf("second spectator joined at frame %d, want 3", snap.Frame)
+	}
+	state, err := snap.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.FormatBoard() != g.FormatBoard() {
+		t.Errorf("second spectator sees:\n%s\nwant:\n%s", state.FormatBoard(), g.FormatBoard())
+	}
+	waitSpectators(t, b, 2)
+
+	b.Close()
+	for _, s := range []*Stream{first, second} {
+		select {
+		case _, ok := <-s.incoming:
+			if ok {
+				t.Error("expected no more snapshots after Close")
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected the stream to end after Close")
+		}
+	}
+}
+
+func TestDial_VersionMismatch(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		fmt.Fprintf(conn, "%s %d\n", magic, Version+1)
+	}()
+
+	if _, err := Dial(l.Addr().String()); !errors.Is(err, ErrVersion) {
+		t.Errorf("Dial error %v, want ErrVersion", err)
+	}
+}
+
+func TestWatch_RendersSnapshots(t *testing.T) {
+	g := playedGame(t)
+	w := NewWatch(nil)
+	m, _ := w.Update(snapshotMsg(Capture(125*60, &g)))
+	m, _ = m.Update(streamClosedMsg{})
+
+	view := ansi.Strip(m.View())
+	for _, want := range []string{"WATCHING 2:05", "1200", "Broadcast ended"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view does not show %q:\n%s", want, view)
+		}
+	}
+}
+
+// writes passes each write to a channel. Follow writes once per frame.
+type writes chan string
+
+func (w writes) Write(p []byte) (int, error) {
+	w <- string(p)
+	return len(p), nil
+}
+
+func TestFollow_WritesChangedCells(t *testing.T) {
+	b, err := Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+	g := playedGame(t)
+	b.Publish(Capture(1, &g))
+
+	s, err := Dial(b.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	out := make(writes, 16)
+	done := make(chan error, 1)
+	go func() { done <- Follow(out, s, 80, 24) }()
+
+	read := func() string {
+		t.Helper()
+		select {
+		case w := <-out:
+			return w
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for output")
+		}
+		return ""
+	}
+	if w := read(); w != hideCursor+clearScreen {
+		t.Errorf("first write %q, want the screen cleared", w)
+	}
+	first := read()
+	if !strings.Contains(ansi.Strip(first), "1200") {
+		t.Errorf("first frame does not show the score: %q", first)
+	}
+
+	waitSpectators(t, b, 1)
+	g.Apply(game.ActionRight)
+	b.Publish(Capture(2, &g))
+	second := read()
+	if len(second) == 0 || len(second) > len(first)/4 {
+		t.Errorf("moving the piece wrote %d bytes after a %d byte frame", len(second), len(first))
+	}
+
+	b.Close()
+	if w := ansi.Strip(read()); !strings.Contains(w, "Broadcast ended") {
+		t.Errorf("last frame %q does not show the end of the broadcast", w)
+	}
+	if w := read(); !strings.HasSuffix(w, showCursor+"\n") {
+		t.Errorf("cursor not restored: %q", w)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Follow: %v", err)
+	}
+}
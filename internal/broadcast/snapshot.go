@@ -0,0 +1,69 @@
+package broadcast
+
+import (
+	"time"
+
+	"termino/internal/game"
+)
+
+// Snapshot is everything a spectator needs to draw a game. The board, pieces
+// and queue travel in the board text format.
+type Snapshot struct {
+	Frame     int           `json:"frame"`
+	Board     string        `json:"board"`
+	Score     int           `json:"score"`
+	Level     int           `json:"level"`
+	Lines     int           `json:"lines"`
+	Pending   int           `json:"pending,omitempty"`
+	Finesse   bool          `json:"finesse,omitempty"` // The finesse fault flash is showing
+	Mode      game.Mode     `json:"mode,omitempty"`
+	LineGoal  int           `json:"line_goal,omitempty"`
+	Elapsed   time.Duration `json:"elapsed,omitempty"`
+	Completed bool          `json:"completed,omitempty"`
+	GameOver  bool          `json:"game_over,omitempty"`
+	Paused    bool          `json:"paused,omitempty"`
+}
+
+// Capture takes a snapshot of g at the given frame.
+func Capture(frame int, g *game.GameState) Snapshot {
+	return Snapshot{
+		Frame:     frame,
+		Board:     g.FormatBoard(),
+		Score:     g.Score,
+		Level:     g.Level,
+		Lines:     g.LinesCleared,
+		Pending:   g.PendingLines(),
+		Finesse:   g.FinesseFlash > 0,
+		Mode:      g.Mode,
+		LineGoal:  g.LineGoal,
+		Elapsed:   g.Elapsed,
+		Completed: g.Completed,
+		GameOver:  g.GameOver,
+		Paused:    g.Paused,
+	}
+}
+
+// State rebuilds a game that draws like the captured one. It cannot be
+// played on, since the randomizer is not part of the snapshot.
+func (s Snapshot) State() (game.GameState, error) {
+	g := game.NewGameStateWithSeed(0)
+	if err := g.LoadBoard(s.Board); err != nil {
+		return g, err
+	}
+	g.Score = s.Score
+	g.Level = s.Level
+	g.LinesCleared = s.Lines
+	if s.Pending > 0 {
+		g.ReceiveGarbage(s.Pending)
+	}
+	if s.Finesse {
+		g.FinesseFlash = 1
+	}
+	g.Mode = s.Mode
+	g.LineGoal = s.LineGoal
+	g.Elapsed = s.Elapsed
+	g.Completed = s.Completed
+	g.GameOver = s.GameOver
+	g.Paused = s.Paused
+	return g, nil
+}
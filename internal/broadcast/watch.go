@@ -0,0 +1,221 @@
+package broadcast
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"termino/internal/game"
+	"termino/pkg/consts"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// dialTimeout bounds connecting and reading the stream header.
+const dialTimeout = 10 * time.Second
+
+// Terminal sequences Follow writes around the frames.
+const (
+	clearScreen = "\x1b[2J"
+	cursorHome  = "\x1b[H"
+	hideCursor  = "\x1b[?25l"
+	showCursor  = "\x1b[?25h"
+)
+
+// Stream receives the snapshots of a broadcast game.
+type Stream struct {
+	conn     net.Conn
+	incoming chan Snapshot
+	err      error // Why incoming was closed
+}
+
+// Dial connects to a broadcaster at addr.
+func Dial(addr string) (*Stream, error) {
+	conn, err := net.DialTimeout("tcp", addr, dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	r := bufio.NewReader(conn)
+
+	conn.SetReadDeadline(time.Now().Add(dialTimeout))
+	header, err := r.ReadString('\n')
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	conn.SetReadDeadline(time.Time{})
+
+	var version int
+	if _, err := fmt.Sscanf(header, magic+" %d\n", &version); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("broadcast: unexpected header %q", strings.TrimSpace(header))
+	}
+	if version != Version {
+		conn.Close()
+		return nil, fmt.Errorf("%w: publisher has %d, we have %d", ErrVersion, version, Version)
+	}
+
+	s := &Stream{conn: conn, incoming: make(chan Snapshot, 64)}
+	go s.readLoop(r)
+	return s, nil
+}
+
+func (s *Stream) readLoop(r *bufio.Reader) {
+	dec := json.NewDecoder(r)
+	for {
+		var snap Snapshot
+		if err := dec.Decode(&snap); err != nil {
+			s.err = err
+			close(s.incoming)
+			return
+		}
+		s.incoming <- snap
+	}
+}
+
+// Close disconnects from the broadcaster.
+func (s *Stream) Close() error {
+	return s.conn.Close()
+}
+
+type (
+	snapshotMsg     Snapshot
+	streamClosedMsg struct{ err error }
+)
+
+// Watch is the spectator view of a broadcast game.
+type Watch struct {
+	Width  int
+	Height int
+
+	stream *Stream
+	state  *game.GameState
+	frame  int
+	status string
+}
+
+// NewWatch creates a spectator view of the game received on s.
+func NewWatch(s *Stream) Watch {
+	return Watch{Width: 80, Height: 24, stream: s}
+}
+
+func (w Watch) Init() tea.Cmd {
+	return w.wait()
+}
+
+// wait delivers the next snapshot.
+func (w Watch) wait() tea.Cmd {
+	s := w.stream
+	return func() tea.Msg {
+		snap, ok := <-s.incoming
+		if !ok {
+			return streamClosedMsg{s.err}
+		}
+		return snapshotMsg(snap)
+	}
+}
+
+func (w Watch) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		w.Width = msg.Width
+		w.Height = msg.Height
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return w, tea.Quit
+		}
+
+	case snapshotMsg:
+		state, err := Snapshot(msg).State()
+		if err != nil {
+			w.status = "Bad snapshot: " + err.Error()
+		} else {
+			w.state, w.frame = &state, msg.Frame
+		}
+		return w, w.wait()
+
+	case streamClosedMsg:
+		w.status = "Broadcast ended"
+		if msg.err != nil && msg.err != io.EOF {
+			w.status = "Disconnected: " + msg.err.Error()
+		}
+	}
+	return w, nil
+}
+
+func (w Watch) View() string {
+	if w.state == nil {
+		if w.status != "" {
+			return w.status + "\n"
+		}
+		return "Waiting for the game...\n"
+	}
+
+	w.draw()
+	return game.ScreenBuffer.Render()
+}
+
+// draw draws the game into game.ScreenBuffer, labelling the board and showing
+// the status below it.
+func (w Watch) draw() {
+	offsetX, offsetY := game.DrawGame(w.state, w.Width, w.Height)
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	if offsetY > 0 {
+		elapsed := time.Duration(w.frame) * time.Second / consts.TickRate
+		game.DrawText(offsetX+1, offsetY-1, fmt.Sprintf("WATCHING %d:%02d", int(elapsed.Minutes()), int(elapsed.Seconds())%60), style)
+	}
+	if w.status != "" {
+		game.DrawText(offsetX, offsetY+consts.VisibleHeight+2, w.status, style)
+	}
+}
+
+// Follow writes the game received on s to out until the broadcast ends or s
+// is closed, sending only the cells that changed since the previous snapshot.
+// Unlike Watch it reads no keys, so out can be a display or a recording.
+func Follow(out io.Writer, s *Stream, width, height int) error {
+	w := Watch{Width: width, Height: height, stream: s}
+	if _, err := io.WriteString(out, hideCursor+clearScreen); err != nil {
+		return err
+	}
+	game.PrepareScreen(width, height)
+	game.ScreenBuffer.Invalidate()
+
+	for snap := range s.incoming {
+		m, _ := w.Update(snapshotMsg(snap))
+		w = m.(Watch)
+		if err := w.flush(out); err != nil {
+			return err
+		}
+	}
+	m, _ := w.Update(streamClosedMsg{s.err})
+	w = m.(Watch)
+	if err := w.flush(out); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(out, "\x1b[%d;1H%s\n", height, showCursor); err != nil {
+		return err
+	}
+	if s.err != io.EOF && !errors.Is(s.err, net.ErrClosed) {
+		return s.err
+	}
+	return nil
+}
+
+// flush writes the cells of the view that changed since the last flush.
+func (w Watch) flush(out io.Writer) error {
+	if w.state == nil {
+		_, err := io.WriteString(out, cursorHome+w.View())
+		return err
+	}
+	w.draw()
+	_, err := io.WriteString(out, game.ScreenBuffer.RenderDiff())
+	return err
+}
@@ -0,0 +1,113 @@
+package fumen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+const encodeTable = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+// commentTable lists the characters a comment may contain after escaping.
+const commentTable = " !\"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\\]^_`abcdefghijklmnopqrstuvwxyz{|}~"
+
+const commentBase = len(commentTable) + 1
+
+// values is a stream of base-64 digits. Multi-digit numbers are little endian.
+type values struct {
+	digits []int
+	pos    int
+}
+
+func newValues(data string) (*values, error) {
+	v := &values{}
+	for _, c := range data {
+		if c == '?' {
+			continue
+		}
+		d := strings.IndexRune(encodeTable, c)
+		if d < 0 {
+			return nil, fmt.Errorf("fumen: invalid character %q", c)
+		}
+		v.digits = append(v.digits, d)
+	}
+	return v, nil
+}
+
+func (v *values) empty() bool { return v.pos >= len(v.digits) }
+
+func (v *values) poll(n int) (int, error) {
+	if v.pos+n > len(v.digits) {
+		return 0, fmt.Errorf("fumen: unexpected end of data")
+	}
+	value := 0
+	for i := n - 1; i >= 0; i-- {
+		value = value*64 + v.digits[v.pos+i]
+	}
+	v.pos += n
+	return value, nil
+}
+
+func (v *values) push(value, n int) {
+	for range n {
+		v.digits = append(v.digits, value%64)
+		value /= 64
+	}
+}
+
+// String renders the digits, inserting '?' separators the way fumen does so
+// that long codes can be wrapped.
+func (v *values) String() string {
+	var sb strings.Builder
+	for i, d := range v.digits {
+		if i >= 42 && (i-42)%47 == 0 {
+			sb.WriteByte('?')
+		}
+		sb.WriteByte(encodeTable[d])
+	}
+	return sb.String()
+}
+
+// escape mirrors JavaScript's escape(), which fumen applies to comments.
+func escape(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z', r >= '0' && r <= '9', strings.ContainsRune("@*_+-./", r):
+			sb.WriteRune(r)
+		case r < 0x100:
+			fmt.Fprintf(&sb, "%%%02X", r)
+		default:
+			for _, u := range utf16.Encode([]rune{r}) {
+				fmt.Fprintf(&sb, "%%u%04X", u)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// unescape mirrors JavaScript's unescape(). Malformed sequences are kept verbatim.
+func unescape(s string) string {
+	var units []uint16
+	for i := 0; i < len(s); i++ {
+		if s[i] == '%' {
+			if i+5 < len(s) && s[i+1] == 'u' {
+				if n, err := strconv.ParseUint(s[i+2:i+6], 16, 16); err == nil {
+					units = append(units, uint16(n))
+					i += 5
+					continue
+				}
+			}
+			if i+2 < len(s) {
+				if n, err := strconv.ParseUint(s[i+1:i+3], 16, 8); err == nil {
+					units = append(units, uint16(n))
+					i += 2
+					continue
+				}
+			}
+		}
+		units = append(units, uint16(s[i]))
+	}
+	return string(utf16.Decode(units))
+}
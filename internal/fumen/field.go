@@ -0,0 +1,71 @@
+package fumen
+
+import "termino/internal/tetromino"
+
+// Cells returns the field coordinates covered by the operation.
+func (op Operation) Cells() [4][2]int {
+	minos := tetromino.Minos(op.Type.Name(), op.Rotation)
+	for i := range minos {
+		minos[i][0] += op.X
+		minos[i][1] += op.Y
+	}
+	return minos
+}
+
+// Apply performs the lock step between pages: place the piece, clear full
+// rows, then optionally raise the garbage row and mirror the field.
+func (f *Field) Apply(op *Operation, rise, mirror bool) {
+	if op != nil && op.Type != Empty && op.Type != Gray {
+		for _, c := range op.Cells() {
+			if c[0] >= 0 && c[0] < FieldWidth && c[1] >= -1 && c[1] < FieldHeight {
+				*f.at(c[0], c[1]) = op.Type
+			}
+		}
+	}
+	f.clearLines()
+	if rise {
+		copy(f.Rows[1:], f.Rows[:FieldHeight-1])
+		f.Rows[0] = f.Garbage
+		f.Garbage = [FieldWidth]Piece{}
+	}
+	if mirror {
+		for y := range f.Rows {
+			row := &f.Rows[y]
+			for x := range FieldWidth / 2 {
+				row[x], row[FieldWidth-1-x] = row[FieldWidth-1-x], row[x]
+			}
+		}
+	}
+}
+
+func (f *Field) clearLines() {
+	write := 0
+	for read := range FieldHeight {
+		full := true
+		for _, c := range f.Rows[read] {
+			if c == Empty {
+				full = false
+				break
+			}
+		}
+		if !full {
+			f.Rows[write] = f.Rows[read]
+			write++
+		}
+	}
+	for ; write < FieldHeight; write++ {
+		f.Rows[write] = [FieldWidth]Piece{}
+	}
+}
+
+// Height returns the number of rows up to and including the highest filled cell.
+func (f *Field) Height() int {
+	for y := FieldHeight - 1; y >= 0; y-- {
+		for _, c := range f.Rows[y] {
+			if c != Empty {
+				return y + 1
+			}
+		}
+	}
+	return 0
+}
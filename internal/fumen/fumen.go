@@ -0,0 +1,407 @@
+// Package fumen decodes and encodes fumen (v115) diagram strings, the format
+// the community uses to share boards and setups.
+package fumen
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	FieldWidth  = 10
+	FieldHeight = 23 // Rows above the garbage row
+
+	fieldBlocks = FieldWidth * (FieldHeight + 1)
+)
+
+// Piece is a fumen cell or piece type.
+type Piece int
+
+const (
+	Empty Piece = iota
+	I
+	L
+	O
+	Z
+	T
+	J
+	S
+	Gray
+)
+
+// String returns the single letter used for the piece in text diagrams.
+func (p Piece) String() string {
+	if p < Empty || p > Gray {
+		return "?"
+	}
+	return string(".ILOZTJSX"[p])
+}
+
+// PieceFromName converts a termino piece name ("I", "T", ...) to a fumen piece.
+func PieceFromName(name string) (Piece, bool) {
+	switch name {
+	case "I":
+		return I, true
+	case "L":
+		return L, true
+	case "O":
+		return O, true
+	case "Z":
+		return Z, true
+	case "T":
+		return T, true
+	case "J":
+		return J, true
+	case "S":
+		return S, true
+	}
+	return Empty, false
+}
+
+// Name returns the termino piece name, or "" for empty and gray cells.
+func (p Piece) Name() string {
+	if p > Empty && p < Gray {
+		return p.String()
+	}
+	return ""
+}
+
+// Field is a fumen playfield. Row 0 is the bottom visible row.
+type Field struct {
+	Rows    [FieldHeight][FieldWidth]Piece
+	Garbage [FieldWidth]Piece // Row below the field raised by the Rise flag
+}
+
+// at returns the cell at x, y where y == -1 addresses the garbage row.
+func (f *Field) at(x, y int) *Piece {
+	if y < 0 {
+		return &f.Garbage[x]
+	}
+	return &f.Rows[y][x]
+}
+
+// Operation is a piece placement. X and Y locate the piece's rotation centre
+// and Rotation uses termino's numbering (0 spawn, 1 right, 2 reverse, 3 left).
+type Operation struct {
+	Type     Piece
+	Rotation int
+	X, Y     int
+}
+
+// Page is one frame of a fumen diagram.
+type Page struct {
+	Field    Field
+	Piece    *Operation // nil when the page has no active piece
+	Comment  string
+	Lock     bool // Place the piece and clear lines before the next page
+	Rise     bool // Raise the garbage row into the field after locking
+	Mirror   bool // Mirror the field after locking
+	Colorize bool // Use guideline colours (only meaningful on the first page)
+}
+
+// fumen stores rotations as reverse, right, spawn, left.
+var (
+	toFumenRotation   = [4]int{2, 1, 0, 3}
+	fromFumenRotation = [4]int{2, 1, 0, 3}
+)
+
+var prefixes = []string{"v115@", "m115@", "d115@", "D115@"}
+
+// Decode parses a fumen string into its pages.
+func Decode(code string) ([]Page, error) {
+	code = strings.TrimSpace(code)
+	if i := strings.LastIndex(code, "?v115@"); i >= 0 {
+		// Accept full URLs such as https://harddrop.com/fumen/?v115@...
+		code = code[i+1:]
+	}
+	var data string
+	for _, p := range prefixes {
+		if strings.HasPrefix(code, p) {
+			data = code[len(p):]
+			break
+		}
+	}
+	if data == "" {
+		return nil, errors.New("fumen: only v115 data is supported")
+	}
+
+	vals, err := newValues(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var pages []Page
+	var prev Field
+	prevComment := ""
+	repeat := 0
+	for !vals.empty() {
+		field := prev
+		if repeat > 0 {
+			repeat--
+		} else {
+			changed, err := decodeField(vals, &field)
+			if err != nil {
+				return nil, err
+			}
+			if !changed {
+				if repeat, err = vals.poll(1); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		v, err := vals.poll(3)
+		if err != nil {
+			return nil, err
+		}
+		page := Page{Field: field}
+		op, hasComment := decodeAction(v, &page)
+		if op.Type != Empty {
+			page.Piece = &op
+		}
+
+		if hasComment {
+			length, err := vals.poll(2)
+			if err != nil {
+				return nil, err
+			}
+			var sb strings.Builder
+			for range (length + 3) / 4 {
+				cv, err := vals.poll(5)
+				if err != nil {
+					return nil, err
+				}
+				for range 4 {
+					idx := cv % commentBase
+					if idx < len(commentTable) {
+						sb.WriteByte(commentTable[idx])
+					}
+					cv /= commentBase
+				}
+			}
+			escaped := sb.String()
+			if len(escaped) > length {
+				escaped = escaped[:length]
+			}
+			prevComment = unescape(escaped)
+		}
+		page.Comment = prevComment
+		if len(pages) > 0 {
+			page.Colorize = pages[0].Colorize
+		}
+		pages = append(pages, page)
+
+		prev = page.Field
+		if page.Lock {
+			prev.Apply(page.Piece, page.Rise, page.Mirror)
+		}
+	}
+
+	if len(pages) == 0 {
+		return nil, errors.New("fumen: no pages")
+	}
+	return pages, nil
+}
+
+// decodeField applies a run-length encoded field diff to f. It reports false
+// when the diff is empty, in which case a repeat count follows.
+func decodeField(vals *values, f *Field) (bool, error) {
+	changed := true
+	for n := 0; n < fieldBlocks; {
+		v, err := vals.poll(2)
+		if err != nil {
+			return false, err
+		}
+		diff, count := v/fieldBlocks, v%fieldBlocks+1
+		if diff == 8 && count == fieldBlocks {
+			changed = false
+		}
+		if n+count > fieldBlocks {
+			return false, errors.New("fumen: field data overflows the board")
+		}
+		for ; count > 0; count-- {
+			x, y := n%FieldWidth, FieldHeight-n/FieldWidth-1
+			cell := f.at(x, y)
+			*cell += Piece(diff - 8)
+			if *cell < Empty || *cell > Gray {
+				return false, fmt.Errorf("fumen: invalid cell value %d", *cell)
+			}
+			n++
+		}
+	}
+	return changed, nil
+}
+
+// decodeAction unpacks the piece and flags of a page and reports whether a comment follows.
+func decodeAction(v int, page *Page) (Operation, bool) {
+	var op Operation
+	op.Type = Piece(v % 8)
+	v /= 8
+	op.Rotation = fromFumenRotation[v%4]
+	v /= 4
+	block := v % fieldBlocks
+	v /= fieldBlocks
+	page.Rise = v%2 == 1
+	v /= 2
+	page.Mirror = v%2 == 1
+	v /= 2
+	page.Colorize = v%2 == 1
+	v /= 2
+	hasComment := v%2 == 1
+	v /= 2
+	page.Lock = v%2 == 0
+
+	op.X = block % FieldWidth
+	op.Y = FieldHeight - block/FieldWidth - 1
+	dx, dy := positionAdjust(op.Type, op.Rotation)
+	op.X += dx
+	op.Y += dy
+	return op, hasComment
+}
+
+// positionAdjust returns the offset from fumen's stored coordinate to the SRS
+// rotation centre for pieces whose legacy centre differs.
+func positionAdjust(p Piece, rot int) (int, int) {
+	switch {
+	case p == O && rot == 3:
+		return 1, -1
+	case p == O && rot == 2:
+		return 1, 0
+	case p == O && rot == 0:
+		return 0, -1
+	case p == I && rot == 2:
+		return 1, 0
+	case p == I && rot == 3:
+		return 0, -1
+	case p == S && rot == 0:
+		return 0, -1
+	case p == S && rot == 1:
+		return -1, 0
+	case p == Z && rot == 0:
+		return 0, -1
+	case p == Z && rot == 3:
+		return 1, 0
+	}
+	return 0, 0
+}
+
+// Encode serialises pages into a v115 fumen string.
+func Encode(pages []Page) (string, error) {
+	if len(pages) == 0 {
+		return "", errors.New("fumen: no pages")
+	}
+
+	vals := &values{}
+	var prev Field
+	prevComment := ""
+	lastRepeat := -1
+	for i := range pages {
+		page := pages[i]
+
+		diff, changed := encodeField(&prev, &page.Field)
+		switch {
+		case changed:
+			vals.digits = append(vals.digits, diff.digits...)
+			lastRepeat = -1
+		case lastRepeat < 0 || vals.digits[lastRepeat] == len(encodeTable)-1:
+			vals.digits = append(vals.digits, diff.digits...)
+			vals.push(0, 1)
+			lastRepeat = len(vals.digits) - 1
+		default:
+			vals.digits[lastRepeat]++
+		}
+
+		hasComment := page.Comment != prevComment
+		escaped := escape(page.Comment)
+		if len(escaped) > 4095 {
+			return "", fmt.Errorf("fumen: comment on page %d is too long", i+1)
+		}
+
+		op := Operation{Type: Empty, Rotation: 2, X: 0, Y: FieldHeight - 1}
+		if page.Piece != nil {
+			op = *page.Piece
+		}
+		block, err := encodePosition(op)
+		if err != nil {
+			return "", fmt.Errorf("fumen: page %d: %w", i+1, err)
+		}
+
+		v := 0
+		if !page.Lock {
+			v = 1
+		}
+		v = v*2 + boolInt(hasComment)
+		v = v*2 + boolInt(i == 0 && page.Colorize)
+		v = v*2 + boolInt(page.Mirror)
+		v = v*2 + boolInt(page.Rise)
+		v = v*fieldBlocks + block
+		v = v*4 + toFumenRotation[op.Rotation&3]
+		v = v*8 + int(op.Type)
+		vals.push(v, 3)
+
+		if hasComment {
+			vals.push(len(escaped), 2)
+			for start := 0; start < len(escaped); start += 4 {
+				cv, mul := 0, 1
+				for j := start; j < start+4 && j < len(escaped); j++ {
+					cv += strings.IndexByte(commentTable, escaped[j]) * mul
+					mul *= commentBase
+				}
+				vals.push(cv, 5)
+			}
+			prevComment = page.Comment
+		}
+
+		prev = page.Field
+		if page.Lock {
+			prev.Apply(page.Piece, page.Rise, page.Mirror)
+		}
+	}
+
+	return "v115@" + vals.String(), nil
+}
+
+// encodeField run-length encodes the difference between prev and cur.
+func encodeField(prev, cur *Field) (*values, bool) {
+	vals := &values{}
+	changed := false
+	diffAt := func(n int) int {
+		x, y := n%FieldWidth, FieldHeight-n/FieldWidth-1
+		return int(*cur.at(x, y)) - int(*prev.at(x, y)) + 8
+	}
+
+	run := diffAt(0)
+	count := 0
+	for n := 1; n < fieldBlocks; n++ {
+		d := diffAt(n)
+		if d != run {
+			vals.push(run*fieldBlocks+count, 2)
+			run, count = d, 0
+			changed = true
+			continue
+		}
+		count++
+	}
+	vals.push(run*fieldBlocks+count, 2)
+	return vals, changed || run != 8
+}
+
+func encodePosition(op Operation) (int, error) {
+	if op.Type == Empty {
+		return 0, nil
+	}
+	dx, dy := positionAdjust(op.Type, op.Rotation&3)
+	x, y := op.X-dx, op.Y-dy
+	if x < 0 || x >= FieldWidth || y < 0 || y >= FieldHeight {
+		return 0, fmt.Errorf("piece position (%d, %d) is outside the field", op.X, op.Y)
+	}
+	return (FieldHeight-y-1)*FieldWidth + x, nil
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,132 @@
+package fumen
+
+import (
+	"testing"
+
+	"termino/internal/game"
+	"termino/internal/tetromino"
+)
+
+func TestDecode_Comment(t *testing.T) {
+	pages, err := Decode("v115@vhARQYHAvItJEJmhCA")
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if len(pages) != 1 {
+		t.Fatalf("Expected 1 page, got %d", len(pages))
+	}
+
+	p := pages[0]
+	if p.Comment != "Opening" {
+		t.Errorf("Expected comment %q, got %q", "Opening", p.Comment)
+	}
+	want := Operation{Type: I, Rotation: 0, X: 4, Y: 0}
+	if p.Piece == nil || *p.Piece != want {
+		t.Errorf("Expected piece %+v, got %+v", want, p.Piece)
+	}
+}
+
+func TestEncodeDecode_RoundTrip(t *testing.T) {
+	var first Page
+	first.Colorize = true
+	first.Lock = true
+	first.Comment = "T-spin double?"
+	for x := range FieldWidth {
+		if x != 4 {
+			first.Field.Rows[0][x] = Gray
+			first.Field.Rows[1][x] = Gray
+		}
+	}
+	first.Field.Rows[2][3] = L
+	first.Piece = &Operation{Type: T, Rotation: 2, X: 4, Y: 1}
+
+	second := Page{Lock: true, Comment: first.Comment, Piece: &Operation{Type: O, Rotation: 0, X: 0, Y: 0}}
+	second.Field = first.Field
+	second.Field.Apply(first.Piece, false, false)
+	third := Page{Lock: false, Comment: "ÿ é 日本", Field: second.Field}
+	third.Field.Apply(second.Piece, false, false)
+
+	code, err := Encode([]Page{first, second, third})
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	pages, err := Decode(code)
+	if err != nil {
+		t.Fatalf("Decode(%q) failed: %v", code, err)
+	}
+	if len(pages) != 3 {
+		t.Fatalf("Expected 3 pages, got %d", len(pages))
+	}
+
+	for i, want := range []Page{first, second, third} {
+		got := pages[i]
+		if got.Field != want.Field {
+			t.Errorf("Page %d: field mismatch", i)
+		}
+		if got.Comment != want.Comment {
+			t.Errorf("Page %d: expected comment %q, got %q", i, want.Comment, got.Comment)
+		}
+		if got.Lock != want.Lock {
+			t.Errorf("Page %d: expected lock %v, got %v", i, want.Lock, got.Lock)
+		}
+		if (got.Piece == nil) != (want.Piece == nil) || (got.Piece != nil && *got.Piece != *want.Piece) {
+			t.Errorf("Page %d: expected piece %+v, got %+v", i, want.Piece, got.Piece)
+		}
+	}
+
+	// The T-spin double leaves only the L stub behind.
+	if pages[1].Field.Height() != 1 || pages[1].Field.Rows[0][3] != L {
+		t.Errorf("Expected the T-spin double to clear two rows")
+	}
+}
+
+func TestOperation_TerminoRoundTrip(t *testing.T) {
+	for _, name := range []string{"I", "J", "L", "O", "S", "T", "Z"} {
+		piece := tetromino.NewTetromino(name)
+		for rot := range 4 {
+			op, err := OperationFor(piece, 3, 30, rot)
+			if err != nil {
+				t.Fatalf("%s rot %d: %v", name, rot, err)
+			}
+			_, x, y, gotRot, err := op.Location()
+			if err != nil {
+				t.Fatalf("%s rot %d: %v", name, rot, err)
+			}
+			if x != 3 || y != 30 || gotRot != rot {
+				t.Errorf("%s rot %d: expected (3, 30, %d), got (%d, %d, %d)", name, rot, rot, x, y, gotRot)
+			}
+		}
+	}
+}
+
+func TestEncodeState(t *testing.T) {
+	state := game.NewGameStateWithSeed(1)
+	state.CurrentPiece = tetromino.NewTetromino("T")
+	state.CurrentX, state.CurrentY, state.CurrentRotation = 3, 37, 2
+	state.Board[39] = 0x3EF
+	state.BoardColors[39][0] = tetromino.ColorJ
+
+	code, err := EncodeState(&state)
+	if err != nil {
+		t.Fatalf("EncodeState failed: %v", err)
+	}
+	pages, err := Decode(code)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+
+	field := pages[0].Field
+	if field.Rows[0][0] != J || field.Rows[0][1] != Gray || field.Rows[0][4] != Empty {
+		t.Errorf("Unexpected bottom row %v", field.Rows[0])
+	}
+	want := Operation{Type: T, Rotation: 2, X: 4, Y: 1}
+	if pages[0].Piece == nil || *pages[0].Piece != want {
+		t.Errorf("Expected piece %+v, got %+v", want, pages[0].Piece)
+	}
+
+	var restored game.GameState
+	pages[0].ApplyField(&restored)
+	if restored.Board[39] != state.Board[39] {
+		t.Errorf("Expected bottom row %#x, got %#x", state.Board[39], restored.Board[39])
+	}
+}
@@ -0,0 +1,100 @@
+package fumen
+
+import (
+	"fmt"
+
+	"termino/internal/game"
+	"termino/internal/tetromino"
+	"termino/pkg/consts"
+)
+
+// boardRow converts a fumen row (0 = bottom) to a termino board row (0 = top).
+func boardRow(y int) int {
+	return consts.BoardHeight - 1 - y
+}
+
+// ApplyField replaces the board and board colours of g with the page's field.
+// The garbage row is not part of the playfield and is ignored.
+func (p *Page) ApplyField(g *game.GameState) {
+	for row := range g.Board {
+		g.Board[row] = 0
+		for x := range g.BoardColors[row] {
+			g.BoardColors[row][x] = ""
+		}
+	}
+	for y := range FieldHeight {
+		row := boardRow(y)
+		if row < 0 {
+			break
+		}
+		for x, c := range p.Field.Rows[y] {
+			if c == Empty {
+				continue
+			}
+			g.Board[row] |= tetromino.Bitmask(1 << x)
+			if name := c.Name(); name != "" {
+				g.BoardColors[row][x] = tetromino.NewTetromino(name).Color
+			}
+		}
+	}
+}
+
+// Location converts the operation to a termino piece and the position of its
+// 4x4 mask on the board.
+func (op Operation) Location() (piece tetromino.Tetromino, x, y, rotation int, err error) {
+	name := op.Type.Name()
+	if name == "" {
+		return piece, 0, 0, 0, fmt.Errorf("fumen: %v is not a placeable piece", op.Type)
+	}
+	piece = tetromino.NewTetromino(name)
+	rotation = op.Rotation & 3
+	x, y = game.CenterToMask(&piece, rotation, op.X, op.Y)
+	return piece, x, y, rotation, nil
+}
+
+// OperationFor converts a termino piece placement into a fumen operation.
+func OperationFor(piece tetromino.Tetromino, x, y, rotation int) (Operation, error) {
+	t, ok := PieceFromName(piece.Name)
+	if !ok {
+		return Operation{}, fmt.Errorf("fumen: unknown piece %q", piece.Name)
+	}
+	op := Operation{Type: t, Rotation: rotation & 3}
+	op.X, op.Y = game.MaskToCenter(&piece, op.Rotation, x, y)
+	return op, nil
+}
+
+// FromState builds a single page from the board and active piece of g.
+func FromState(g *game.GameState) (Page, error) {
+	page := Page{Lock: true, Colorize: true}
+	for y := range FieldHeight {
+		row := boardRow(y)
+		for x := range consts.BoardWidth {
+			if g.Board[row]&tetromino.Bitmask(1<<x) == 0 {
+				continue
+			}
+			cell := Gray
+			if name, ok := tetromino.FromColor(g.BoardColors[row][x]); ok {
+				cell, _ = PieceFromName(name)
+			}
+			page.Field.Rows[y][x] = cell
+		}
+	}
+
+	if !g.GameOver && g.CurrentPiece.Name != "" {
+		op, err := OperationFor(g.CurrentPiece, g.CurrentX, g.CurrentY, g.CurrentRotation)
+		if err != nil {
+			return page, err
+		}
+		page.Piece = &op
+	}
+	return page, nil
+}
+
+// EncodeState returns the fumen string for the board and active piece of g.
+func EncodeState(g *game.GameState) (string, error) {
+	page, err := FromState(g)
+	if err != nil {
+		return "", err
+	}
+	return Encode([]Page{page})
+}
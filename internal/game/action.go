@@ -0,0 +1,101 @@
+package game
+
+// Action is a single gameplay input. The keyboard, replays and bots all drive
+// the game through the same set of actions.
+type Action string
+
+const (
+	ActionLeft      Action = "LEFT"
+	ActionRight     Action = "RIGHT"
+	ActionSoftDrop  Action = "SOFT_DROP"
+	ActionHardDrop  Action = "HARD_DROP"
+	ActionRotateCW  Action = "ROTATE_CW"
+	ActionRotateCCW Action = "ROTATE_CCW"
+	ActionRotate180 Action = "ROTATE_180"
+	ActionHold      Action = "HOLD"
+
+	// DAS moves slide the piece to the wall. They are used by the finesse
+	// tables; the keyboard produces them as runs of repeated moves.
+	ActionDASLeft  Action = "DAS_LEFT"
+	ActionDASRight Action = "DAS_RIGHT"
+)
+
+// DefaultKeymap maps bubbletea key strings to gameplay actions.
+var DefaultKeymap = map[string]Action{
+	"left":  ActionLeft,
+	"right": ActionRight,
+	"down":  ActionSoftDrop,
+	" ":     ActionHardDrop,
+	"up":    ActionRotateCW,
+	"x":     ActionRotateCW,
+	"c":     ActionRotateCCW,
+	"v":     ActionRotate180,
+	"z":     ActionHold,
+}
+
+// Apply performs a single gameplay action on the state.
+func (g *GameState) Apply(a Action) {
+	g.Stats.Inputs++
+	g.countInput(a)
+	defer g.collapseDAS()
+
+	switch a {
+	case ActionLeft:
+		if g.canPlace(g.CurrentPiece.Name, g.CurrentX-1, g.CurrentY, g.CurrentRotation) {
+			g.CurrentX--
+			g.lastRotated = false
+			g.resetLockDelay()
+			g.UpdateGhost()
+		}
+	case ActionRight:
+		if g.canPlace(g.CurrentPiece.Name, g.CurrentX+1, g.CurrentY, g.CurrentRotation) {
+			g.CurrentX++
+			g.lastRotated = false
+			g.resetLockDelay()
+			g.UpdateGhost()
+		}
+	case ActionDASLeft, ActionDASRight:
+		dx := -1
+		if a == ActionDASRight {
+			dx = 1
+		}
+		for g.canPlace(g.CurrentPiece.Name, g.CurrentX+dx, g.CurrentY, g.CurrentRotation) {
+			g.CurrentX += dx
+			g.lastRotated = false
+			g.resetLockDelay()
+		}
+		g.UpdateGhost()
+	case ActionSoftDrop:
+		if g.canPlace(g.CurrentPiece.Name, g.CurrentX, g.CurrentY+1, g.CurrentRotation) {
+			g.CurrentY++
+			g.lastRotated = false
+			g.Score++
+		}
+	case ActionRotateCW:
+		g.RotateCW()
+		g.UpdateGhost()
+	case ActionRotateCCW:
+		g.RotateCCW()
+		g.UpdateGhost()
+	case ActionRotate180:
+		// Rotate 180 degrees (rotate twice)
+		g.RotateCW()
+		g.RotateCW()
+		g.UpdateGhost()
+	case ActionHardDrop:
+		dropDist := 0
+		for g.canPlace(g.CurrentPiece.Name, g.CurrentX, g.CurrentY+1, g.CurrentRotation) {
+			g.CurrentY++
+			dropDist++
+		}
+		if dropDist > 0 {
+			g.lastRotated = false
+		}
+		g.Score += dropDist * 2
+		g.LockPiece()
+		g.UpdateGhost()
+	case ActionHold:
+		g.HoldCurrentPiece()
+		g.UpdateGhost()
+	}
+}
@@ -0,0 +1,72 @@
+package game
+
+import (
+	"termino/internal/tetromino"
+	"termino/pkg/consts"
+)
+
+// Suggestion is a move recommended for the current piece.
+type Suggestion struct {
+	Hold      bool      // Swap with the hold piece before placing
+	Placement Placement // Placement of the piece that is active after the optional hold
+}
+
+// Advisor recommends moves. It is implemented by the built-in bot and used to
+// autoplay or to hint.
+type Advisor interface {
+	Suggest(g *GameState) (Suggestion, bool)
+}
+
+// HoldPreview returns the piece that would become active after holding and
+// the position it would start from, without changing the game.
+// It reports false when holding is not allowed.
+func (g *GameState) HoldPreview() (piece tetromino.Tetromino, x, y int, ok bool) {
+	if g.HoldUsed {
+		return piece, 0, 0, false
+	}
+	if g.HoldPiece == nil {
+		return g.NextQueue[0], consts.BoardWidth/2 - 2, 20, true
+	}
+	return *g.HoldPiece, consts.BoardWidth/2 - 2, 18, true
+}
+
+// PathTo returns the shortest input sequence from the current position to the
+// cells of p, or false if p is no longer reachable.
+func (g *GameState) PathTo(p Placement) ([]Action, bool) {
+	found, ok := FindPlacement(g.Placements(), &g.CurrentPiece, p)
+	if !ok || found.Spin != p.Spin {
+		return nil, false
+	}
+	return found.Inputs, true
+}
+
+// FindPlacement returns the placement of piece among placements that covers
+// the same cells as target, preferring one with the same spin.
+func FindPlacement(placements []Placement, piece *tetromino.Tetromino, target Placement) (Placement, bool) {
+	want := placementKey{}
+	want.top, want.rows = cellRows(piece, target.X, target.Y, target.Rotation)
+
+	var match Placement
+	found := false
+	for _, p := range placements {
+		key := placementKey{}
+		key.top, key.rows = cellRows(piece, p.X, p.Y, p.Rotation)
+		if key != want {
+			continue
+		}
+		if p.Spin == target.Spin {
+			return p, true
+		}
+		match, found = p, true
+	}
+	return match, found
+}
+
+// Inputs returns the full action sequence for the suggestion, starting from
+// the position the placement was generated for.
+func (s Suggestion) Inputs() []Action {
+	if s.Hold {
+		return append([]Action{ActionHold}, s.Placement.Inputs...)
+	}
+	return s.Placement.Inputs
+}
@@ -0,0 +1,187 @@
+package game
+
+import (
+	"strings"
+
+	"termino/internal/tetromino"
+	"termino/pkg/consts"
+)
+
+// TSpin is the kind of T-spin a locked piece scored.
+type TSpin int
+
+const (
+	TSpinNone TSpin = iota
+	TSpinMini
+	TSpinFull
+)
+
+// Clear describes what the last locked piece cleared.
+type Clear struct {
+	Lines        int
+	TSpin        TSpin
+	B2B          bool // Continued a back-to-back chain of difficult clears
+	Combo        int  // Consecutive clears before this one
+	PerfectClear bool
+	Attack       int // Garbage lines sent, before cancelling incoming garbage
+}
+
+// Difficult reports whether the clear continues a back-to-back chain.
+func (c Clear) Difficult() bool {
+	return c.Lines == 4 || (c.Lines > 0 && c.TSpin != TSpinNone)
+}
+
+var clearNames = [...]string{"", "SINGLE", "DOUBLE", "TRIPLE", "TETRIS"}
+
+// Name returns the clear as shown to players, such as "T-SPIN DOUBLE" or
+// "B2B TETRIS", or "" if nothing noteworthy happened.
+func (c Clear) Name() string {
+	var parts []string
+	if c.B2B {
+		parts = append(parts, "B2B")
+	}
+	switch c.TSpin {
+	case TSpinMini:
+		parts = append(parts, "T-SPIN MINI")
+	case TSpinFull:
+		parts = append(parts, "T-SPIN")
+	}
+	if c.Lines > 0 {
+		parts = append(parts, clearNames[min(c.Lines, 4)])
+	}
+	return strings.Join(parts, " ")
+}
+
+// Guideline attack tables, indexed by lines cleared.
+var (
+	lineAttack  = [...]int{0, 0, 1, 2, 4}
+	tSpinAttack = [...]int{0, 2, 4, 6}
+	miniAttack  = [...]int{0, 0, 1}
+
+	// comboAttack is indexed by the number of clears before this one.
+	comboAttack = [...]int{0, 1, 1, 2, 2, 3, 3, 4, 4, 4, 5}
+)
+
+const (
+	b2bAttack          = 1
+	perfectClearAttack = 10
+)
+
+// attack returns the garbage lines the clear sends.
+func (c Clear) attack() int {
+	if c.Lines == 0 {
+		return 0
+	}
+
+	var sent int
+	switch c.TSpin {
+	case TSpinFull:
+		sent = tSpinAttack[min(c.Lines, 3)]
+	case TSpinMini:
+		sent = miniAttack[min(c.Lines, 2)]
+	default:
+		sent = lineAttack[min(c.Lines, 4)]
+	}
+	if c.B2B {
+		sent += b2bAttack
+	}
+	sent += comboAttack[min(c.Combo, len(comboAttack)-1)]
+	if c.PerfectClear {
+		sent += perfectClearAttack
+	}
+	return sent
+}
+
+// Guideline scores, indexed by lines cleared.
+var (
+	lineScore  = [...]int{0, 100, 300, 500, 800}
+	tSpinScore = [...]int{400, 800, 1200, 1600}
+	miniScore  = [...]int{100, 200, 400}
+)
+
+// score returns the points for the clear before the level multiplier.
+func (c Clear) score() int {
+	var points int
+	switch c.TSpin {
+	case TSpinFull:
+		points = tSpinScore[min(c.Lines, 3)]
+	case TSpinMini:
+		points = miniScore[min(c.Lines, 2)]
+	default:
+		points = lineScore[min(c.Lines, 4)]
+	}
+	if c.B2B {
+		points += points / 2
+	}
+	if c.Lines > 0 {
+		points += 50 * c.Combo
+	}
+	return points
+}
+
+// tSpin classifies the active piece by the three-corner rule. The last
+// successful input must have been a rotation, and at least three corners of
+// the T's 3x3 box must be blocked. It is a full T-spin when both corners the
+// T points at are blocked or the rotation used the last kick, otherwise a mini.
+func (g *GameState) tSpin() TSpin {
+	if g.CurrentPiece.Name != "T" || !g.lastRotated {
+		return TSpinNone
+	}
+
+	blocked := func(dx, dy int) bool {
+		x, y := g.CurrentX+dx, g.CurrentY+dy
+		if x < 0 || x >= consts.BoardWidth || y >= consts.BoardHeight {
+			return true
+		}
+		return y >= 0 && g.Board[y]&tetromino.Bitmask(1<<x) != 0
+	}
+	// Corners clockwise from top left, so the two the T points at in
+	// rotation r are r and r+1.
+	corners := [4]bool{blocked(0, 0), blocked(2, 0), blocked(2, 2), blocked(0, 2)}
+
+	count := 0
+	for _, c := range corners {
+		if c {
+			count++
+		}
+	}
+	if count < 3 {
+		return TSpinNone
+	}
+	r := g.CurrentRotation
+	if (corners[r] && corners[(r+1)%4]) || g.lastKick == 4 {
+		return TSpinFull
+	}
+	return TSpinMini
+}
+
+// scoreClear records a lock that cleared lines (possibly none) and updates
+// score, level, combo and back-to-back state.
+func (g *GameState) scoreClear(lines int, spin TSpin) Clear {
+	c := Clear{
+		Lines:        lines,
+		TSpin:        spin,
+		Combo:        g.Combo,
+		PerfectClear: lines > 0 && g.Board[consts.BoardHeight-1] == 0,
+	}
+	if lines > 0 {
+		if c.Difficult() {
+			c.B2B = g.BackToBack
+			g.BackToBack = true
+		} else {
+			g.BackToBack = false
+		}
+		g.Combo++
+	} else {
+		c.Combo = 0
+		g.Combo = 0
+	}
+	c.Attack = c.attack()
+
+	g.Score += c.score() * g.Level
+	g.LinesCleared += lines
+	g.updateLevel()
+	g.LastClear = c
+	g.Stats.record(c)
+	return c
+}
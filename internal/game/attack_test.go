@@ -0,0 +1,129 @@
+package game
+
+import "testing"
+
+func TestClear_Attack(t *testing.T) {
+	tests := []struct {
+		name  string
+		clear Clear
+		want  int
+	}{
+		{"single", Clear{Lines: 1}, 0},
+		{"double", Clear{Lines: 2}, 1},
+		{"triple", Clear{Lines: 3}, 2},
+		{"tetris", Clear{Lines: 4}, 4},
+		{"b2b tetris", Clear{Lines: 4, B2B: true}, 5},
+		{"t-spin single", Clear{Lines: 1, TSpin: TSpinFull}, 2},
+		{"t-spin double", Clear{Lines: 2, TSpin: TSpinFull}, 4},
+		{"b2b t-spin triple", Clear{Lines: 3, TSpin: TSpinFull, B2B: true}, 7},
+		{"t-spin mini single", Clear{Lines: 1, TSpin: TSpinMini}, 0},
+		{"t-spin mini double", Clear{Lines: 2, TSpin: TSpinMini}, 1},
+		{"t-spin without lines", Clear{TSpin: TSpinFull}, 0},
+		{"double on combo 4", Clear{Lines: 2, Combo: 4}, 3},
+		{"single on combo 20", Clear{Lines: 1, Combo: 20}, 5},
+		{"perfect clear tetris", Clear{Lines: 4, PerfectClear: true}, 14},
+	}
+	for _, tt := range tests {
+		if got := tt.clear.attack(); got != tt.want {
+			t.Errorf("%s: attack = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestClear_Name(t *testing.T) {
+	tests := []struct {
+		clear Clear
+		want  string
+	}{
+		{Clear{}, ""},
+		{Clear{Lines: 1}, "SINGLE"},
+		{Clear{Lines: 4, B2B: true}, "B2B TETRIS"},
+		{Clear{Lines: 2, TSpin: TSpinFull}, "T-SPIN DOUBLE"},
+		{Clear{Lines: 1, TSpin: TSpinMini}, "T-SPIN MINI SINGLE"},
+		{Clear{TSpin: TSpinFull}, "T-SPIN"},
+	}
+	for _, tt := range tests {
+		if got := tt.clear.Name(); got != tt.want {
+			t.Errorf("%+v: Name = %q, want %q", tt.clear, got, tt.want)
+		}
+	}
+}
+
+func TestLockPiece_TSpinDouble(t *testing.T) {
+	state := NewGameStateWithSeed(1)
+	if err := state.LoadBoard(`
+current: T
+GG........
+G...GGGGGG
+GG.GGGGGGG`); err != nil {
+		t.Fatalf("LoadBoard failed: %v", err)
+	}
+
+	var spin *Placement
+	for _, p := range state.Placements() {
+		if p.Spin && p.Rotation == 2 {
+			spin = &p
+		}
+	}
+	if spin == nil {
+		t.Fatal("no T-spin placement found")
+	}
+	inputs := spin.Inputs[:len(spin.Inputs)-1]
+	for _, a := range inputs {
+		state.Apply(a)
+	}
+	before := state.Score
+	state.Apply(ActionHardDrop)
+
+	c := state.LastClear
+	if c.Lines != 2 || c.TSpin != TSpinFull || c.Attack != 4 {
+		t.Errorf("LastClear = %+v, want a T-spin double sending 4", c)
+	}
+	if !state.BackToBack || state.Combo != 1 {
+		t.Errorf("BackToBack = %v, Combo = %d after the first difficult clear", state.BackToBack, state.Combo)
+	}
+	if got := state.Score - before; got != 1200 {
+		t.Errorf("scored %d, want 1200", got)
+	}
+}
+
+func TestLockPiece_NoSpinWithoutRotation(t *testing.T) {
+	state := NewGameStateWithSeed(1)
+	if err := state.LoadBoard(`
+current: T
+G...GGGGGG
+GG.GGGGGGG
+G.GGGGGGGG`); err != nil {
+		t.Fatalf("LoadBoard failed: %v", err)
+	}
+	state.Apply(ActionRotateCW)
+	state.Apply(ActionRotateCW)
+	state.Apply(ActionLeft)
+	state.Apply(ActionLeft)
+	state.Apply(ActionHardDrop)
+
+	if c := state.LastClear; c.Lines != 2 || c.TSpin != TSpinNone || c.Attack != 1 {
+		t.Errorf("LastClear = %+v, want a plain double", c)
+	}
+}
+
+func TestLockPiece_NoSpinAfterHardDrop(t *testing.T) {
+	// Rotate in the air, then hard drop into three blocked corners.
+	state := NewGameStateWithSeed(1)
+	if err := state.LoadBoard(`
+current: T
+G.........
+G.........
+G.G.......`); err != nil {
+		t.Fatalf("LoadBoard failed: %v", err)
+	}
+	for range 3 {
+		state.Apply(ActionLeft)
+	}
+	state.Apply(ActionRotateCW)
+	state.Apply(ActionHardDrop)
+
+	if c := state.LastClear; c.TSpin != TSpinNone {
+		t.Errorf("LastClear = %+v, want no T-spin", c)
+	}
+}
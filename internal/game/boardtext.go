@@ -0,0 +1,265 @@
+package game
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+	"unicode"
+
+	"termino/internal/tetromino"
+	"termino/pkg/consts"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Board text format
+//
+// A board is written as an optional header followed by a grid:
+//
+//	current: T
+//	hold: I
+//	queue: SZOLJ
+//	..........
+//	....t.....
+//	...ttt....
+//	GGGG.GGGGG
+//
+// Grid rows are bottom-aligned, so the last line is the bottom of the board
+// and empty rows above the stack may be left out.
+// Cells are '.' for empty, a piece letter for a block of that colour, 'G' for
+// garbage and '#' for a block of any other colour. Lower-case letters mark the
+// active piece; without them the current piece starts at the spawn position.
+// Shapes shared by several rotations (vertical I, S and Z) load in the lowest
+// matching rotation.
+
+const (
+	garbageCell = 'G'
+	otherCell   = '#'
+	emptyCell   = '.'
+)
+
+// otherColor is used for '#' cells so they survive a round trip.
+var otherColor = lipgloss.Color("#888888")
+
+// FormatBoard renders the board, active piece, hold and queue as text.
+func (g *GameState) FormatBoard() string {
+	var sb strings.Builder
+	if g.CurrentPiece.Name != "" {
+		fmt.Fprintf(&sb, "current: %s\n", g.CurrentPiece.Name)
+	}
+	if g.HoldPiece != nil {
+		fmt.Fprintf(&sb, "hold: %s\n", g.HoldPiece.Name)
+	}
+	if len(g.NextQueue) > 0 {
+		sb.WriteString("queue: ")
+		for _, p := range g.NextQueue {
+			sb.WriteString(p.Name)
+		}
+		sb.WriteByte('\n')
+	}
+
+	var active [consts.BoardHeight]tetromino.Bitmask
+	if g.CurrentPiece.Name != "" && !g.GameOver {
+		active = pieceRows(g.CurrentPiece, g.CurrentX, g.CurrentY, g.CurrentRotation)
+	}
+
+	top := consts.BoardHeight
+	for row := range consts.BoardHeight {
+		if g.Board[row] != 0 || active[row] != 0 {
+			top = row
+			break
+		}
+	}
+
+	for row := top; row < consts.BoardHeight; row++ {
+		for x := range consts.BoardWidth {
+			bit := tetromino.Bitmask(1 << x)
+			switch {
+			case active[row]&bit != 0:
+				sb.WriteRune(unicode.ToLower(rune(g.CurrentPiece.Name[0])))
+			case g.Board[row]&bit != 0:
+				sb.WriteRune(cellRune(g.BoardColors[row][x]))
+			default:
+				sb.WriteRune(emptyCell)
+			}
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// LoadBoard replaces the board, active piece, hold and queue with those
+// described by text. Queue pieces are dealt before the randomizer's.
+func (g *GameState) LoadBoard(text string) error {
+	var (
+		grid    []string
+		current string
+		hold    string
+		queue   string
+	)
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, ":"); ok {
+			if len(grid) > 0 {
+				return fmt.Errorf("line %d: header after board rows", i+1)
+			}
+			value = strings.ToUpper(strings.TrimSpace(value))
+			switch strings.ToLower(strings.TrimSpace(key)) {
+			case "current":
+				current = value
+			case "hold":
+				hold = value
+			case "queue":
+				queue = value
+			default:
+				return fmt.Errorf("line %d: unknown header %q", i+1, key)
+			}
+			for _, r := range value {
+				if !isPieceName(r) {
+					return fmt.Errorf("line %d: unknown piece %q", i+1, r)
+				}
+			}
+			continue
+		}
+		if len(line) != consts.BoardWidth {
+			return fmt.Errorf("line %d: expected %d cells, got %d", i+1, consts.BoardWidth, len(line))
+		}
+		grid = append(grid, line)
+	}
+	if len(grid) > consts.BoardHeight {
+		return fmt.Errorf("board has %d rows, at most %d are allowed", len(grid), consts.BoardHeight)
+	}
+	if len(current) > 1 || len(hold) > 1 {
+		return fmt.Errorf("current and hold take a single piece")
+	}
+
+	var board [consts.BoardHeight]tetromino.Bitmask
+	var colors [consts.BoardHeight][consts.BoardWidth]lipgloss.Color
+	var active [consts.BoardHeight]tetromino.Bitmask
+	activeName := ""
+	start := consts.BoardHeight - len(grid)
+	for i, line := range grid {
+		row := start + i
+		for x, r := range line {
+			bit := tetromino.Bitmask(1 << x)
+			switch {
+			case r == emptyCell:
+			case r == garbageCell:
+				board[row] |= bit
+			case r == otherCell:
+				board[row] |= bit
+				colors[row][x] = otherColor
+			case isPieceName(r):
+				board[row] |= bit
+				colors[row][x] = tetromino.NewTetromino(string(r)).Color
+			case isPieceName(unicode.ToUpper(r)):
+				name := string(unicode.ToUpper(r))
+				if activeName != "" && activeName != name {
+					return fmt.Errorf("row %d: active piece cells mix %s and %s", i+1, activeName, name)
+				}
+				activeName = name
+				active[row] |= bit
+			default:
+				return fmt.Errorf("row %d: unknown cell %q", i+1, r)
+			}
+		}
+	}
+
+	if activeName != "" && current != "" && current != activeName {
+		return fmt.Errorf("current piece %s does not match the active cells (%s)", current, activeName)
+	}
+
+	g.Board = board
+	g.BoardColors = colors
+	g.GameOver = false
+	if hold != "" {
+		piece := tetromino.NewTetromino(hold)
+		g.HoldPiece = &piece
+	} else {
+		g.HoldPiece = nil
+	}
+	if queue != "" {
+		pieces := make([]tetromino.Tetromino, 0, max(len(queue), consts.PreviewCount))
+		for _, r := range queue {
+			pieces = append(pieces, tetromino.NewTetromino(string(r)))
+		}
+		for len(pieces) < consts.PreviewCount {
+			pieces = append(pieces, g.Randomizer.Next())
+		}
+		g.NextQueue = pieces
+	}
+
+	switch {
+	case activeName != "":
+		piece := tetromino.NewTetromino(activeName)
+		x, y, rot, ok := locatePiece(piece, active)
+		if !ok {
+			return fmt.Errorf("active cells do not form a %s piece", activeName)
+		}
+		g.SpawnPiece(piece)
+		g.CurrentX, g.CurrentY, g.CurrentRotation = x, y, rot
+		g.GameOver = false
+	case current != "":
+		g.SpawnPiece(tetromino.NewTetromino(current))
+	}
+	g.UpdateGhost()
+	return nil
+}
+
+// pieceRows returns the board rows covered by piece at the given position.
+func pieceRows(piece tetromino.Tetromino, x, y, rot int) [consts.BoardHeight]tetromino.Bitmask {
+	var rows [consts.BoardHeight]tetromino.Bitmask
+	for r, mask := range piece.Masks[rot] {
+		row := y + r
+		if mask == 0 || row < 0 || row >= consts.BoardHeight {
+			continue
+		}
+		if x >= 0 {
+			rows[row] = mask << x
+		} else {
+			rows[row] = mask >> -x
+		}
+	}
+	return rows
+}
+
+// locatePiece finds the position and rotation at which piece covers exactly the cells in rows.
+func locatePiece(piece tetromino.Tetromino, rows [consts.BoardHeight]tetromino.Bitmask) (x, y, rot int, ok bool) {
+	cells := 0
+	for _, r := range rows {
+		cells += bits.OnesCount16(uint16(r))
+	}
+	if cells != 4 {
+		return 0, 0, 0, false
+	}
+
+	// Candidates that shift cells off the left edge lose them, so they can
+	// never cover all four cells and need no special handling.
+	for rot := range 4 {
+		for y := -3; y < consts.BoardHeight; y++ {
+			for x := -3; x < consts.BoardWidth; x++ {
+				if pieceRows(piece, x, y, rot) == rows {
+					return x, y, rot, true
+				}
+			}
+		}
+	}
+	return 0, 0, 0, false
+}
+
+func cellRune(c lipgloss.Color) rune {
+	if c == "" {
+		return garbageCell
+	}
+	if name, ok := tetromino.FromColor(c); ok {
+		return rune(name[0])
+	}
+	return otherCell
+}
+
+func isPieceName(r rune) bool {
+	return strings.ContainsRune("IJLOSTZ", r)
+}
@@ -0,0 +1,62 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"termino/internal/tetromino"
+)
+
+func TestBoardText_RoundTrip(t *testing.T) {
+	text := strings.TrimLeft(`
+current: T
+hold: I
+queue: SZOLJ
+...t......
+..ttt....#
+IIJJ.LOOSZ
+GGGGG.GGGG
+`, "\n")
+
+	state := NewGameStateWithSeed(1)
+	if err := state.LoadBoard(text); err != nil {
+		t.Fatalf("LoadBoard failed: %v", err)
+	}
+
+	if state.CurrentPiece.Name != "T" || state.CurrentRotation != 0 || state.CurrentX != 2 || state.CurrentY != 36 {
+		t.Errorf("Expected T at (2, 36) rot 0, got %s at (%d, %d) rot %d",
+			state.CurrentPiece.Name, state.CurrentX, state.CurrentY, state.CurrentRotation)
+	}
+	if state.HoldPiece == nil || state.HoldPiece.Name != "I" {
+		t.Errorf("Expected hold piece I, got %v", state.HoldPiece)
+	}
+	if state.Board[39] != 0x3DF {
+		t.Errorf("Expected garbage row 0x3DF, got %#x", state.Board[39])
+	}
+	if state.BoardColors[38][5] != tetromino.ColorL {
+		t.Errorf("Expected L colour at (5, 38), got %q", state.BoardColors[38][5])
+	}
+
+	if got := state.FormatBoard(); got != text {
+		t.Errorf("Round trip mismatch:\n got:\n%s\nwant:\n%s", got, text)
+	}
+}
+
+func TestBoardText_Errors(t *testing.T) {
+	cases := map[string]string{
+		"short row":      "....",
+		"unknown cell":   "....X.....",
+		"unknown piece":  "queue: IQ",
+		"mixed active":   "...tt.....\n...ii.....",
+		"not a piece":    "t.t.t.t...",
+		"header at end":  "..........\nhold: I",
+		"wrong current":  "current: S\n.t........\nttt.......",
+		"unknown header": "next: I",
+	}
+	for name, text := range cases {
+		state := NewGameStateWithSeed(1)
+		if err := state.LoadBoard(text); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
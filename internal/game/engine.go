@@ -3,23 +3,121 @@ package game
 import (
 	"time"
 
+	"termino/internal/render"
+	"termino/internal/settings"
+
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 type tickMsg time.Time
 
+// hintKey identifies the position a hint was computed for. The hint changes
+// only when a piece locks or the current piece is swapped with hold.
+type hintKey struct {
+	placed int  // PiecesPlaced
+	held   bool // Hold was used on the current piece
+}
+
+// hintMsg delivers a hint requested by updateHint.
+type hintMsg struct {
+	state      *GameState // Snapshot the advisor was asked about
+	at         hintKey
+	suggestion Suggestion
+	ok         bool
+}
+
 type Model struct {
 	State            GameState
 	Width            int
 	Height           int
+	Frame            int     // Ticks simulated since the game started
+	Replay           *Replay // Inputs recorded for the current game, nil for custom starting positions
+	Bot              Advisor // Plays the game instead of the keyboard when set, asked from a command so slow bots do not stall the game
+	BotInterval      int     // Frames between bot inputs
+	Hints            Advisor // Recommends placements for the hint overlay
+	ShowHint         bool    // Draw the hint overlay, toggled with 'h'
+	ShowStats        bool    // Draw the live stats panel, toggled with 's'
 	lastSpacePressed bool
+	hint             *Suggestion
+	hintAt           hintKey    // Position hint was computed for
+	hintValid        bool       // hint is up to date for hintAt
+	hintAsked        *GameState // Snapshot of the hint request in flight, if any
+	pilot            autopilot
+	Publish          func(frame int, state *GameState) // Called after every input and tick, e.g. to broadcast the game
+	Finished         func(state *GameState)            // Called once when a game ends, e.g. to record its statistics
+	SaveSlot         func(save SavedGame)              // Called with the game in progress when the player quits or suspends
+	finished         bool
+	Settings         settings.Settings         // Tunables edited in the settings menu, opened with 'o'
+	SettingsSaved    func(s settings.Settings) // Called when the settings menu is confirmed, e.g. to write the settings file
+	menu             *SettingsMenu
+	menuPaused       bool // Paused state from before the menu was opened
+	pauseCursor      pauseItem
+	countdown        int             // Frames left before play starts or resumes
+	setup            Setup           // How 'r' starts the next game
+	QuitHint         string          // Shown on the game over screen, e.g. when 'q' returns to a menu
+	Recorded         func(r *Replay) // Called with the replay once a game ends, e.g. to keep it
+	Screen           *render.Buffer  // Buffer View draws into, ScreenBuffer when nil
 }
 
 func NewModel() Model {
+	return NewModelWithSeed(time.Now().UnixNano())
+}
+
+// NewModelWithSeed creates a model whose piece sequence is fully determined by seed.
+func NewModelWithSeed(seed int64) Model {
+	return Model{
+		State:     NewGameStateWithSeed(seed),
+		Width:     80, // Default fallback
+		Height:    24,
+		Replay:    NewReplay(seed),
+		Settings:  settings.Default(),
+		countdown: countdownFrames,
+	}
+}
+
+// NewModelWithMode creates a model for a game of the given mode.
+func NewModelWithMode(seed int64, mode Mode) Model {
+	m := NewModelWithSeed(seed)
+	m.setup = Setup{Mode: mode}
+	m.setup.apply(&m.State, m.Replay)
+	return m
+}
+
+// NewModelWithSetup creates a model for games started as described by s.
+// Restarting with 'r' keeps the setup, including a fixed seed.
+func NewModelWithSetup(s Setup) Model {
+	seed := s.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	m := NewModelWithSeed(seed)
+	m.setup = s
+	s.apply(&m.State, m.Replay)
+	return m
+}
+
+// NewModelFromState creates a model that starts from a prepared position.
+// Such games cannot be reproduced from a seed, so no replay is recorded.
+func NewModelFromState(state GameState) Model {
 	return Model{
-		State:  NewGameState(),
-		Width:  80, // Default fallback
-		Height: 24,
+		State:     state,
+		Width:     80,
+		Height:    24,
+		Settings:  settings.Default(),
+		countdown: countdownFrames,
+	}
+}
+
+// UseSettings makes the model play and draw with s. The lock delay only
+// changes before the game has started, since a replay has a single one.
+func (m *Model) UseSettings(s settings.Settings) {
+	m.Settings = s
+	if m.Frame == 0 {
+		m.State.LockDelay = s.LockDelayDuration()
+		if m.Replay != nil {
+			m.Replay.LockDelay = m.State.LockDelay
+		}
 	}
 }
 
@@ -39,87 +137,257 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.menu != nil {
+			m.updateMenu(msg)
+			return m, nil
+		}
+
+		if m.State.Paused && !m.State.GameOver {
+			if cmd, ok := m.updatePause(msg); ok {
+				return m, cmd
+			}
+		}
+
 		// Global Controls
 		switch msg.String() {
 		case "ctrl+c", "q":
+			m.saveProgress()
 			return m, tea.Quit
+		case "ctrl+z":
+			m.saveProgress()
+			m.pause()
+			return m, tea.Suspend
 		case "p", "esc":
-			m.State.Paused = !m.State.Paused
-			// If unpausing, we simply continue. Ticks are always running.
+			if !m.State.GameOver {
+				m.pause()
+			}
 		case "r":
-			m.State = NewGameState() // Reset
-			m.State.SpawnNewPiece()  // Ensure piece matches fix
-			m.State.UpdateGhost()
+			m.restart()
+		case "s":
+			m.ShowStats = !m.ShowStats
+		case "o":
+			m.openSettings()
+			return m, nil
+		case "h":
+			if m.Hints != nil {
+				m.ShowHint = !m.ShowHint
+				m.hint, m.hintValid, m.hintAsked = nil, false, nil
+			}
 		}
 
-		if m.State.GameOver || m.State.Paused {
+		if m.State.GameOver || m.State.Paused || m.countdown > 0 || m.Bot != nil {
 			return m, nil
 		}
 
 		// Gameplay Input
-		switch msg.String() {
-		case "left":
-			if m.State.canPlace(m.State.CurrentPiece.Name, m.State.CurrentX-1, m.State.CurrentY, m.State.CurrentRotation) {
-				m.State.CurrentX--
-				m.State.resetLockDelay()
-				m.State.UpdateGhost()
-			}
-		case "right":
-			if m.State.canPlace(m.State.CurrentPiece.Name, m.State.CurrentX+1, m.State.CurrentY, m.State.CurrentRotation) {
-				m.State.CurrentX++
-				m.State.resetLockDelay()
-				m.State.UpdateGhost()
-			}
-		case "down":
-			if m.State.canPlace(m.State.CurrentPiece.Name, m.State.CurrentX, m.State.CurrentY+1, m.State.CurrentRotation) {
-				m.State.CurrentY++
-				m.State.Score++
-			}
-		case "up", "x":
-			m.State.RotateCW()
-			m.State.UpdateGhost()
-		case "c":
-			m.State.RotateCCW()
-			m.State.UpdateGhost()
-		case "v":
-			// Rotate 180 degrees (rotate twice)
-			m.State.RotateCW()
-			m.State.RotateCW()
-			m.State.UpdateGhost()
-		case " ":
+		action, ok := DefaultKeymap[msg.String()]
+		if !ok {
+			return m, nil
+		}
+		if action == ActionHardDrop {
 			// Hard Drop - only trigger if not already pressed
-			if !m.lastSpacePressed {
-				m.lastSpacePressed = true
-				dropDist := 0
-				for m.State.canPlace(m.State.CurrentPiece.Name, m.State.CurrentX, m.State.CurrentY+1, m.State.CurrentRotation) {
-					m.State.CurrentY++
-					dropDist++
-				}
-				m.State.Score += dropDist * 2
-				m.State.LockPiece()
-				m.State.UpdateGhost()
+			if m.lastSpacePressed {
+				return m, nil
 			}
-		case "z":
-			m.State.HoldCurrentPiece()
-			m.State.UpdateGhost()
+			m.lastSpacePressed = true
 		}
+		m.apply(action)
+		m.publish()
 
 	case tickMsg:
 		// Reset space bar pressed flag each tick to allow next press
 		m.lastSpacePressed = false
 
-		// Stop physics if paused/over
-		if !m.State.Paused && !m.State.GameOver {
+		// Stop physics if paused/over, and hold it during the countdown
+		var ask, hint tea.Cmd
+		switch {
+		case m.State.Paused || m.State.GameOver:
+		case m.countdown > 0:
+			m.countdown--
+		default:
+			if m.Bot != nil {
+				ask = m.pilot.stepAsync(&m.State, m.botPilot(), m.apply)
+			}
 			m.State.ApplyGravity(1.0 / 60.0)
+			m.Frame++
+			if m.Replay != nil {
+				m.Replay.Frames = m.Frame
+			}
+			hint = m.updateHint()
 		}
+		m.publish()
 
-		return m, tea.Tick(time.Second/60, func(t time.Time) tea.Msg {
+		return m, tea.Batch(ask, hint, tea.Tick(time.Second/60, func(t time.Time) tea.Msg {
 			return tickMsg(t)
-		})
+		}))
+
+	case suggestionMsg:
+		m.pilot.deliver(&m.State, m.botPilot(), msg)
+
+	case hintMsg:
+		if msg.state != m.hintAsked {
+			break
+		}
+		m.hintAsked = nil
+		if msg.ok && msg.at == m.currentHintKey() {
+			m.hint = &msg.suggestion
+		}
 	}
 	return m, nil
 }
 
+func (m *Model) botPilot() Pilot {
+	return Pilot{Bot: m.Bot, Interval: m.BotInterval}
+}
+
+// restart starts a new game with the same setup. A fixed seed deals the same
+// pieces again.
+func (m *Model) restart() {
+	seed := m.setup.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	m.RestartWithSeed(seed)
+}
+
+// RestartWithSeed starts a new game with the same setup, dealing the pieces
+// of seed.
+func (m *Model) RestartWithSeed(seed int64) {
+	m.State = NewGameStateWithSeed(seed)
+	m.Replay = NewReplay(seed)
+	m.setup.apply(&m.State, m.Replay)
+	if d := m.Settings.LockDelayDuration(); d > 0 {
+		m.State.LockDelay = d
+		m.Replay.LockDelay = d
+	}
+	m.Frame = 0
+	m.countdown = countdownFrames
+	m.finished = false
+	m.pilot = autopilot{}
+	m.hint, m.hintValid, m.hintAsked = nil, false, nil
+}
+
+// openSettings pauses the game under the settings menu.
+func (m *Model) openSettings() {
+	m.menu = NewSettingsMenu(m.Settings)
+	m.menuPaused = m.State.Paused
+	m.State.Paused = true
+}
+
+// apply performs a gameplay action and records it in the replay.
+func (m *Model) apply(action Action) {
+	m.State.Apply(action)
+	if m.Replay != nil {
+		m.Replay.Record(m.Frame, action)
+	}
+}
+
+func (m *Model) publish() {
+	if m.Publish != nil {
+		m.Publish(m.Frame, &m.State)
+	}
+	if m.State.GameOver && !m.finished {
+		m.finished = true
+		if m.Finished != nil {
+			m.Finished(&m.State)
+		}
+		if m.Recorded != nil && m.Replay != nil {
+			m.Recorded(m.Replay)
+		}
+	}
+}
+
+// display returns the settings the game is drawn with, previewing those being
+// edited while the settings menu is open.
+func (m *Model) display() settings.Settings {
+	if m.menu != nil {
+		return m.menu.Settings
+	}
+	return m.Settings
+}
+
+// updateMenu passes a key to the open settings menu, previewing its settings
+// and applying them once it is confirmed.
+func (m *Model) updateMenu(key tea.KeyMsg) {
+	done, save := m.menu.Update(key)
+	if !done {
+		return
+	}
+	if save {
+		m.Settings = m.menu.Settings
+		if m.SettingsSaved != nil {
+			m.SettingsSaved(m.Settings)
+		}
+	}
+	m.menu = nil
+	if m.menuPaused {
+		return
+	}
+	m.resume()
+}
+
+// saveProgress hands the game to SaveSlot unless it has already ended.
+func (m *Model) saveProgress() {
+	if m.SaveSlot != nil && !m.State.GameOver {
+		m.SaveSlot(m.Save())
+	}
+}
+
+// updateHint asks the advisor for a new hint whenever a piece is placed or
+// held. The search runs in the returned command, and the answer is shown only
+// if it is still for the current position when it arrives.
+func (m *Model) updateHint() tea.Cmd {
+	if !m.ShowHint || m.Hints == nil || m.hintAsked != nil {
+		return nil
+	}
+	at := m.currentHintKey()
+	if m.hintValid && at == m.hintAt {
+		return nil
+	}
+	m.hint = nil
+	m.hintAt, m.hintValid = at, true
+	snapshot := m.State.snapshot()
+	m.hintAsked = snapshot
+	hints := m.Hints
+	return func() tea.Msg {
+		s, ok := hints.Suggest(snapshot)
+		return hintMsg{state: snapshot, at: at, suggestion: s, ok: ok}
+	}
+}
+
+// currentHintKey returns the key of the position being played.
+func (m *Model) currentHintKey() hintKey {
+	return hintKey{placed: m.State.PiecesPlaced, held: m.State.HoldUsed}
+}
+
 func (m Model) View() string {
-	return RenderGame(&m.State, m.Width, m.Height)
+	var hint *Suggestion
+	if m.ShowHint {
+		hint = m.hint
+	}
+	b := m.screen()
+	x, y := drawGame(b, &m.State, hint, m.display(), m.Width, m.Height)
+	if m.ShowStats {
+		drawStats(b, &m.State, x, y)
+	}
+	if m.State.GameOver && m.QuitHint != "" {
+		writeString(b, x+6, y+15, m.QuitHint, lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")))
+	}
+	switch {
+	case m.menu != nil:
+		m.menu.draw(b, x, y)
+	case m.State.Paused && !m.State.GameOver:
+		m.drawPause(b, x, y)
+	case !m.State.GameOver:
+		m.drawCountdown(b, x, y)
+	}
+	return b.Render()
+}
+
+// screen returns the buffer View draws into.
+func (m Model) screen() *render.Buffer {
+	if m.Screen != nil {
+		return m.Screen
+	}
+	return screen()
 }
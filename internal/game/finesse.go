@@ -0,0 +1,189 @@
+package game
+
+import (
+	"sync"
+	"time"
+
+	"termino/internal/tetromino"
+	"termino/pkg/consts"
+)
+
+// finesseFlashTime is how long the HUD shows a finesse fault.
+const finesseFlashTime = time.Second
+
+// Finesse is the cheapest way to reach a placement from spawn on an empty board.
+type Finesse struct {
+	Inputs []Action // Taps, DAS moves and rotations, without the final hard drop
+}
+
+// finesseMoves are the inputs of the standard two-step finesse tables: taps,
+// DAS to the wall and single rotations.
+var finesseMoves = [...]Action{
+	ActionLeft,
+	ActionRight,
+	ActionDASLeft,
+	ActionDASRight,
+	ActionRotateCW,
+	ActionRotateCCW,
+}
+
+// finesseKey identifies a final placement by the shape and columns of its
+// cells, ignoring the row it lands on.
+type finesseKey struct {
+	piece string
+	rows  [4]tetromino.Bitmask
+}
+
+var (
+	finesseOnce  sync.Once
+	finesseTable map[finesseKey]Finesse
+)
+
+// FinesseFor returns the optimal inputs for placing piece at x in rotation,
+// or false if no such placement exists on an empty board.
+func FinesseFor(piece *tetromino.Tetromino, x, rotation int) (Finesse, bool) {
+	finesseOnce.Do(buildFinesseTable)
+	_, rows := cellRows(piece, x, 0, rotation)
+	f, ok := finesseTable[finesseKey{piece.Name, rows}]
+	return f, ok
+}
+
+// buildFinesseTable searches every piece's placements breadth first from the
+// spawn position, so the first path to each placement is a shortest one.
+func buildFinesseTable() {
+	finesseTable = make(map[finesseKey]Finesse)
+	var board Board
+
+	for _, name := range []string{"I", "J", "L", "O", "S", "T", "Z"} {
+		piece := tetromino.NewTetromino(name)
+		start := genState{consts.BoardWidth/2 - 2, 20, 0}
+		paths := map[genState][]Action{start: nil}
+		queue := []genState{start}
+
+		for len(queue) > 0 {
+			s := queue[0]
+			queue = queue[1:]
+
+			_, rows := cellRows(&piece, s.x, 0, s.rot)
+			key := finesseKey{name, rows}
+			if _, ok := finesseTable[key]; !ok {
+				finesseTable[key] = Finesse{Inputs: paths[s]}
+			}
+
+			for _, move := range finesseMoves {
+				next, ok := finesseStep(&board, &piece, s, move)
+				if !ok {
+					continue
+				}
+				if _, seen := paths[next]; seen {
+					continue
+				}
+				paths[next] = append(paths[s][:len(paths[s]):len(paths[s])], move)
+				queue = append(queue, next)
+			}
+		}
+	}
+}
+
+// finesseStep applies a finesse input, treating a DAS move as sliding to the wall.
+func finesseStep(board *Board, piece *tetromino.Tetromino, s genState, move Action) (genState, bool) {
+	dir := ActionLeft
+	switch move {
+	case ActionDASRight:
+		dir = ActionRight
+	case ActionDASLeft:
+	default:
+		return genStep(board, piece, s, move)
+	}
+
+	moved := false
+	for {
+		next, ok := genStep(board, piece, s, dir)
+		if !ok {
+			return s, moved
+		}
+		s, moved = next, true
+	}
+}
+
+// countInput adds a to the current piece's input count. A run of repeated
+// moves in one direction that ends blocked is counted as a single DAS input,
+// since a held key reaches the game as a stream of repeats.
+func (g *GameState) countInput(a Action) {
+	switch a {
+	case ActionHold, ActionHardDrop:
+		return
+	case ActionSoftDrop:
+		g.pieceSoftDropped = true
+	}
+	if (a == ActionLeft || a == ActionRight) && a == g.inputRun {
+		g.inputRunLen++
+	} else {
+		g.inputRun, g.inputRunStart, g.inputRunLen = a, g.PieceInputs, 1
+	}
+	g.PieceInputs = g.inputRunStart + g.inputRunLen
+}
+
+// collapseDAS counts the current run of moves as one input once it is blocked.
+func (g *GameState) collapseDAS() {
+	dx := 0
+	switch g.inputRun {
+	case ActionLeft:
+		dx = -1
+	case ActionRight:
+		dx = 1
+	default:
+		return
+	}
+	if !g.canPlace(g.CurrentPiece.Name, g.CurrentX+dx, g.CurrentY, g.CurrentRotation) {
+		g.PieceInputs = g.inputRunStart + 1
+	}
+}
+
+// minPieceInputs returns the fewest inputs the active piece can end up
+// counted as, assuming a run of moves still in progress becomes a DAS.
+func (g *GameState) minPieceInputs() int {
+	if g.inputRun == ActionLeft || g.inputRun == ActionRight {
+		return g.inputRunStart + 1
+	}
+	return g.PieceInputs
+}
+
+// resetInputs starts counting inputs for a new active piece.
+func (g *GameState) resetInputs() {
+	g.PieceInputs = 0
+	g.inputRun, g.inputRunStart, g.inputRunLen = "", 0, 0
+	g.pieceSoftDropped = false
+}
+
+// judgeFinesse compares the inputs spent on the piece being locked with the
+// optimal ones. Pieces that were soft dropped or tucked under an overhang are
+// not judged, since the empty-board tables do not apply to them.
+func (g *GameState) judgeFinesse() {
+	if g.pieceSoftDropped {
+		return
+	}
+	for y := 20; y < g.CurrentY; y++ {
+		if !g.canPlace(g.CurrentPiece.Name, g.CurrentX, y, g.CurrentRotation) {
+			return
+		}
+	}
+	f, ok := FinesseFor(&g.CurrentPiece, g.CurrentX, g.CurrentRotation)
+	if !ok {
+		return
+	}
+
+	g.FinessePieces++
+	if g.PieceInputs > len(f.Inputs) {
+		g.FinesseFaults++
+		g.FinesseFlash = finesseFlashTime
+	}
+}
+
+// FinessePercent returns the share of judged pieces placed without a fault.
+func (g *GameState) FinessePercent() float64 {
+	if g.FinessePieces == 0 {
+		return 100
+	}
+	return 100 * float64(g.FinessePieces-g.FinesseFaults) / float64(g.FinessePieces)
+}
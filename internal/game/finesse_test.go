@@ -0,0 +1,95 @@
+package game
+
+import (
+	"testing"
+
+	"termino/internal/tetromino"
+)
+
+func TestFinesseFor_CoversEmptyBoard(t *testing.T) {
+	for _, name := range []string{"I", "J", "L", "O", "S", "T", "Z"} {
+		state := NewGameStateWithSeed(1)
+		state.SpawnPiece(tetromino.NewTetromino(name))
+
+		for _, p := range state.Placements() {
+			f, ok := FinesseFor(&state.CurrentPiece, p.X, p.Rotation)
+			if !ok {
+				t.Errorf("%s: no finesse for (%d, %d) rot %d", name, p.X, p.Y, p.Rotation)
+				continue
+			}
+			if len(f.Inputs) > 4 {
+				t.Errorf("%s: %v needs more than four inputs", name, f.Inputs)
+			}
+
+			replay := state
+			for _, a := range f.Inputs {
+				replay.Apply(a)
+			}
+			if replay.PieceInputs != len(f.Inputs) {
+				t.Errorf("%s: %v counted as %d inputs", name, f.Inputs, replay.PieceInputs)
+			}
+			replay.Apply(ActionHardDrop)
+			if replay.FinesseFaults != 0 || replay.FinessePieces != 1 {
+				t.Errorf("%s: optimal inputs %v judged %d/%d", name, f.Inputs, replay.FinesseFaults, replay.FinessePieces)
+			}
+		}
+	}
+}
+
+func TestFinesseFor_KnownPlacements(t *testing.T) {
+	tests := []struct {
+		piece  string
+		x, rot int
+		want   int
+	}{
+		{"T", 3, 0, 0}, // Spawn position
+		{"T", 0, 0, 1}, // DAS left
+		{"T", 2, 0, 1}, // Tap left
+		{"T", 3, 2, 2}, // Two rotations
+		{"O", 1, 0, 2}, // Two taps or DAS and tap
+		{"I", 6, 0, 1}, // DAS right
+	}
+	for _, tt := range tests {
+		piece := tetromino.NewTetromino(tt.piece)
+		f, ok := FinesseFor(&piece, tt.x, tt.rot)
+		if !ok {
+			t.Errorf("%s at %d rot %d: not found", tt.piece, tt.x, tt.rot)
+			continue
+		}
+		if len(f.Inputs) != tt.want {
+			t.Errorf("%s at %d rot %d: %v, want %d inputs", tt.piece, tt.x, tt.rot, f.Inputs, tt.want)
+		}
+	}
+}
+
+func TestFinesse_Faults(t *testing.T) {
+	tests := []struct {
+		name   string
+		inputs []Action
+		judged int
+		faults int
+	}{
+		{"held key to the wall", []Action{ActionLeft, ActionLeft, ActionLeft, ActionLeft}, 1, 0},
+		{"taps", []Action{ActionRight, ActionRight}, 1, 0},
+		{"back and forth", []Action{ActionRight, ActionLeft}, 1, 1},
+		{"three rotations", []Action{ActionRotateCW, ActionRotateCW, ActionRotateCW}, 1, 1},
+		{"soft drop", []Action{ActionSoftDrop, ActionLeft, ActionRight}, 0, 0},
+		{"hold resets", []Action{ActionRight, ActionLeft, ActionHold}, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := NewGameStateWithSeed(1)
+			state.SpawnPiece(tetromino.NewTetromino("T"))
+			for _, a := range tt.inputs {
+				state.Apply(a)
+			}
+			state.Apply(ActionHardDrop)
+			if state.FinessePieces != tt.judged || state.FinesseFaults != tt.faults {
+				t.Errorf("judged %d, faults %d; want %d, %d", state.FinessePieces, state.FinesseFaults, tt.judged, tt.faults)
+			}
+			if tt.faults > 0 && state.FinesseFlash == 0 {
+				t.Error("fault not flashed")
+			}
+		})
+	}
+}
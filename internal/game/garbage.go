@@ -0,0 +1,109 @@
+package game
+
+import (
+	"termino/internal/tetromino"
+	"termino/pkg/consts"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// garbageCap is the most garbage lines that enter the board per locked piece.
+// The rest stays pending.
+const garbageCap = 8
+
+// garbageSeed derives the garbage hole sequence's seed from the game seed, so
+// holes are reproducible but independent of the piece sequence.
+func garbageSeed(seed int64) int64 {
+	return seed ^ 0x5DEECE66D
+}
+
+// ReceiveGarbage queues a batch of incoming garbage lines. Pending garbage is
+// cancelled by outgoing attack and otherwise enters the board when a piece
+// locks without clearing lines.
+func (g *GameState) ReceiveGarbage(lines int) {
+	if lines > 0 {
+		g.PendingGarbage = append(g.PendingGarbage, lines)
+	}
+}
+
+// PendingLines returns the total incoming garbage waiting to enter the board.
+func (g *GameState) PendingLines() int {
+	total := 0
+	for _, n := range g.PendingGarbage {
+		total += n
+	}
+	return total
+}
+
+// cancelGarbage uses attack to cancel pending garbage, oldest first, and
+// returns the attack left to send.
+func (g *GameState) cancelGarbage(attack int) int {
+	pending := make([]int, 0, len(g.PendingGarbage))
+	for _, n := range g.PendingGarbage {
+		cancel := min(n, attack)
+		attack -= cancel
+		if n > cancel {
+			pending = append(pending, n-cancel)
+		}
+	}
+	g.PendingGarbage = pending
+	return attack
+}
+
+// insertPendingGarbage moves up to garbageCap pending lines onto the board.
+// Each batch gets a new hole column; within a batch the hole moves with
+// probability Messiness per row.
+func (g *GameState) insertPendingGarbage() {
+	if g.garbageRand == nil {
+		g.garbageRand, g.garbageSrc = newCountedRand(garbageSeed(g.Seed), 0)
+	}
+
+	budget := garbageCap
+	pending := make([]int, 0, len(g.PendingGarbage))
+	for _, n := range g.PendingGarbage {
+		take := min(n, budget)
+		budget -= take
+		if take > 0 {
+			hole := g.garbageRand.Intn(consts.BoardWidth)
+			for i := range take {
+				if i > 0 && g.garbageRand.Float64() < g.Messiness {
+					hole = (hole + 1 + g.garbageRand.Intn(consts.BoardWidth-1)) % consts.BoardWidth
+				}
+				g.AddGarbage(1, hole)
+			}
+		}
+		if n > take {
+			pending = append(pending, n-take)
+		}
+	}
+	g.PendingGarbage = pending
+}
+
+// AddGarbage pushes the board up by lines and fills the new bottom rows except
+// for the hole column. Blocks pushed off the top end the game. The active
+// piece is moved up if the rising stack would overlap it.
+func (g *GameState) AddGarbage(lines, hole int) {
+	if lines <= 0 {
+		return
+	}
+	lines = min(lines, consts.BoardHeight)
+
+	for y := range lines {
+		if g.Board[y] != 0 {
+			g.end(EndGarbage)
+		}
+	}
+	copy(g.Board[:], g.Board[lines:])
+	copy(g.BoardColors[:], g.BoardColors[lines:])
+
+	row := boardMask &^ tetromino.Bitmask(1<<hole)
+	for y := consts.BoardHeight - lines; y < consts.BoardHeight; y++ {
+		g.Board[y] = row
+		g.BoardColors[y] = [consts.BoardWidth]lipgloss.Color{}
+	}
+
+	for !g.canPlace(g.CurrentPiece.Name, g.CurrentX, g.CurrentY, g.CurrentRotation) && g.CurrentY > 0 {
+		g.CurrentY--
+	}
+	g.UpdateGhost()
+}
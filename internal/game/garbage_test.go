@@ -0,0 +1,104 @@
+package game
+
+import (
+	"slices"
+	"testing"
+
+	"termino/internal/tetromino"
+	"termino/pkg/consts"
+)
+
+func TestAddGarbage(t *testing.T) {
+	state := NewGameStateWithSeed(1)
+	state.Board[consts.BoardHeight-1] = 0x00F
+
+	state.AddGarbage(2, 3)
+
+	want := boardMask &^ (1 << 3)
+	for _, y := range []int{consts.BoardHeight - 1, consts.BoardHeight - 2} {
+		if state.Board[y] != want {
+			t.Errorf("row %d = %010b, want %010b", y, state.Board[y], want)
+		}
+	}
+	if state.Board[consts.BoardHeight-3] != 0x00F {
+		t.Errorf("existing row not pushed up: %010b", state.Board[consts.BoardHeight-3])
+	}
+	if state.GameOver {
+		t.Error("unexpected game over")
+	}
+
+	state.Board[1] = 1
+	state.AddGarbage(2, 0)
+	if !state.GameOver || state.Ending != EndGarbage {
+		t.Errorf("blocks pushed off the top ended the game %v, reason %q", state.GameOver, state.EndReason())
+	}
+}
+
+func TestGarbage_Cancellation(t *testing.T) {
+	state := NewGameStateWithSeed(1)
+	state.ReceiveGarbage(3)
+	state.ReceiveGarbage(2)
+
+	if left := state.cancelGarbage(4); left != 0 {
+		t.Errorf("cancelGarbage(4) left %d to send", left)
+	}
+	if !slices.Equal(state.PendingGarbage, []int{1}) {
+		t.Errorf("pending = %v, want [1]", state.PendingGarbage)
+	}
+	if left := state.cancelGarbage(3); left != 2 {
+		t.Errorf("cancelGarbage(3) left %d to send, want 2", left)
+	}
+	if state.PendingLines() != 0 {
+		t.Errorf("pending = %v, want none", state.PendingGarbage)
+	}
+}
+
+func TestGarbage_EntersOnLockWithoutClear(t *testing.T) {
+	state := NewGameStateWithSeed(1)
+	state.ReceiveGarbage(3)
+	state.ReceiveGarbage(7)
+	state.SpawnPiece(tetromino.NewTetromino("O"))
+	state.Apply(ActionHardDrop)
+
+	rows := 0
+	holes := map[tetromino.Bitmask]bool{}
+	for y := consts.BoardHeight - 1; y >= 0; y-- {
+		if bitsSet(state.Board[y]) == consts.BoardWidth-1 {
+			rows++
+			holes[state.Board[y]] = true
+		}
+	}
+	if rows != garbageCap {
+		t.Errorf("%d garbage rows entered, want %d", rows, garbageCap)
+	}
+	if !slices.Equal(state.PendingGarbage, []int{2}) {
+		t.Errorf("pending = %v, want [2]", state.PendingGarbage)
+	}
+	// Messiness is 0, so the first batch shares one hole; the second may differ.
+	if len(holes) > 2 {
+		t.Errorf("%d distinct holes in two clean batches", len(holes))
+	}
+}
+
+func TestGarbage_Deterministic(t *testing.T) {
+	run := func() Board {
+		state := NewGameStateWithSeed(7)
+		state.Messiness = 0.5
+		state.ReceiveGarbage(6)
+		state.Apply(ActionHardDrop)
+		return state.Board
+	}
+	if run() != run() {
+		t.Error("same seed produced different garbage")
+	}
+}
+
+func bitsSet(row tetromino.Bitmask) int {
+	n := 0
+	for x := range consts.BoardWidth {
+		if row&(1<<x) != 0 {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,80 @@
+package game
+
+import (
+	"termino/internal/tetromino"
+	"termino/pkg/consts"
+)
+
+// Pieces can also be located by their SRS rotation centre with x to the right
+// and y counting up from the bottom row, as fumen and the Tetris Bot Protocol do.
+
+// CenterToMask converts a rotation centre to the board position of the piece's 4x4 mask.
+func CenterToMask(piece *tetromino.Tetromino, rot, cx, cy int) (x, y int) {
+	minX, maxY := minoBounds(piece.Name, rot)
+	maskCol, maskRow := maskBounds(piece.Masks[rot])
+	return cx + minX - maskCol, consts.BoardHeight - 1 - (cy + maxY) - maskRow
+}
+
+// MaskToCenter converts the board position of a piece's 4x4 mask to its rotation centre.
+func MaskToCenter(piece *tetromino.Tetromino, rot, x, y int) (cx, cy int) {
+	minX, maxY := minoBounds(piece.Name, rot)
+	maskCol, maskRow := maskBounds(piece.Masks[rot])
+	return x + maskCol - minX, consts.BoardHeight - 1 - (y + maskRow) - maxY
+}
+
+// minoBounds returns the leftmost and highest offsets of a piece's cells around its centre.
+func minoBounds(name string, rot int) (minX, maxY int) {
+	minos := tetromino.Minos(name, rot)
+	minX, maxY = minos[0][0], minos[0][1]
+	for _, m := range minos[1:] {
+		minX = min(minX, m[0])
+		maxY = max(maxY, m[1])
+	}
+	return minX, maxY
+}
+
+// maskBounds returns the leftmost column and topmost row set in a piece mask.
+func maskBounds(mask [4]tetromino.Bitmask) (col, row int) {
+	col, row = 4, 4
+	for r := range 4 {
+		for c := range 4 {
+			if mask[r]&tetromino.Bitmask(1<<c) != 0 {
+				col = min(col, c)
+				row = min(row, r)
+			}
+		}
+	}
+	return col, row
+}
+
+// PlaceOnBoard locks piece at p on board and clears full rows, returning the
+// number of rows cleared. It is the board-only counterpart of LockPiece for
+// simulations that do not need a GameState.
+func PlaceOnBoard(board *Board, piece *tetromino.Tetromino, p Placement) int {
+	for r, mask := range piece.Masks[p.Rotation] {
+		row := p.Y + r
+		if mask == 0 || row < 0 || row >= consts.BoardHeight {
+			continue
+		}
+		if p.X >= 0 {
+			board[row] |= mask << p.X
+		} else {
+			board[row] |= mask >> -p.X
+		}
+	}
+
+	lines := 0
+	write := consts.BoardHeight - 1
+	for read := consts.BoardHeight - 1; read >= 0; read-- {
+		if board[read]&boardMask == boardMask {
+			lines++
+			continue
+		}
+		board[write] = board[read]
+		write--
+	}
+	for ; write >= 0; write-- {
+		board[write] = 0
+	}
+	return lines
+}
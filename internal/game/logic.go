@@ -1,6 +1,7 @@
 package game
 
 import (
+	"math"
 	"termino/internal/tetromino"
 	"termino/pkg/consts"
 	"time"
@@ -9,12 +10,25 @@ import (
 // ApplyGravity updates piece position based on elapsed time and current level.
 // Gravity speed increases with level, ranging from 1.25 rows/sec at level 1 to 20+ at higher levels.
 func (g *GameState) ApplyGravity(dt float64) {
+	if g.GameOver {
+		return
+	}
+	g.Elapsed += time.Duration(math.Round(dt * float64(time.Second)))
+	if g.checkGoal(); g.GameOver {
+		return
+	}
+
+	if g.FinesseFlash > 0 {
+		g.FinesseFlash -= time.Duration(dt * float64(time.Second))
+	}
+
 	speed := g.calculateGravitySpeed()
 	g.GravityAccumulator += dt * speed
 
 	for g.GravityAccumulator >= 1.0 {
 		if g.canPlace(g.CurrentPiece.Name, g.CurrentX, g.CurrentY+1, g.CurrentRotation) {
 			g.CurrentY++
+			g.lastRotated = false
 			g.resetLockDelay()
 			g.UpdateGhost()
 		} else {
@@ -50,6 +64,8 @@ func (g *GameState) handleTouchdown(dt float64) {
 
 // LockPiece burns the current piece onto the board, clears completed lines, and spawns a new piece.
 func (g *GameState) LockPiece() {
+	g.judgeFinesse()
+
 	masks := g.CurrentPiece.Masks[g.CurrentRotation]
 	for row := 0; row < 4; row++ {
 		boardRow := g.CurrentY + row
@@ -77,7 +93,25 @@ func (g *GameState) LockPiece() {
 		}
 	}
 
-	g.ClearLines()
+	g.PiecesPlaced++
+	spin := g.tSpin()
+	clear := g.scoreClear(g.ClearLines(), spin)
+
+	// Attack cancels incoming garbage first; garbage that is left enters the
+	// board when the piece cleared nothing.
+	if clear.Attack > 0 {
+		sent := g.cancelGarbage(clear.Attack)
+		g.Outgoing += sent
+		g.AttackSent += sent
+	}
+	if clear.Lines == 0 {
+		g.insertPendingGarbage()
+	}
+
+	g.checkGoal()
+	if g.GameOver {
+		return
+	}
 	g.SpawnNewPiece()
 	g.UpdateGhost()
 }
@@ -91,6 +125,7 @@ func (g *GameState) HoldCurrentPiece() {
 	if g.HoldUsed {
 		return
 	}
+	g.Stats.Holds++
 
 	if g.HoldPiece == nil {
 		piece := g.CurrentPiece
@@ -104,14 +139,16 @@ func (g *GameState) HoldCurrentPiece() {
 		g.CurrentX = consts.BoardWidth/2 - 2
 		g.CurrentY = 18
 		g.CurrentRotation = 0
+		g.lastRotated = false
 		g.resetLockDelay()
+		g.resetInputs()
 	}
 
 	g.HoldUsed = true
 }
 
-// ClearLines removes completed rows and updates score and level accordingly.
-func (g *GameState) ClearLines() {
+// ClearLines removes completed rows and returns how many were cleared.
+func (g *GameState) ClearLines() int {
 	fullLineMask := uint16(0x03FF)
 	linesCleared := 0
 
@@ -135,39 +172,7 @@ func (g *GameState) ClearLines() {
 		writeY--
 	}
 
-	g.updateScore(linesCleared)
-	g.updateLevel()
-}
-
-// updateScore calculates and applies the score multiplier based on lines cleared.
-// Follows Tetris guideline: 100/300/500/800 points per line, multiplied by level.
-// Back-to-back tetris (4-line clears) doubles the 800-point base.
-func (g *GameState) updateScore(linesCleared int) {
-	baseScore := g.calculateLineScore(linesCleared)
-	g.Score += baseScore * g.Level
-	g.LinesCleared += linesCleared
-}
-
-// calculateLineScore returns the base score for the given number of cleared lines.
-func (g *GameState) calculateLineScore(lines int) int {
-	switch lines {
-	case 1:
-		g.BackToBack = false
-		return 100
-	case 2:
-		g.BackToBack = false
-		return 300
-	case 3:
-		g.BackToBack = false
-		return 500
-	case 4:
-		if g.BackToBack {
-			return 1200
-		}
-		g.BackToBack = true
-		return 800
-	}
-	return 0
+	return linesCleared
 }
 
 // updateLevel increases level every 10 cleared lines.
@@ -0,0 +1,131 @@
+package game
+
+import (
+	"fmt"
+	"time"
+)
+
+// Mode is the goal a game is played towards.
+type Mode string
+
+const (
+	ModeMarathon Mode = "marathon" // Play until topping out
+	ModeSprint   Mode = "sprint"   // Clear the line goal, SprintLines by default, as fast as possible
+	ModeUltra    Mode = "ultra"    // Score as much as possible in UltraTime
+)
+
+// Modes lists every mode, in the order menus show them.
+var Modes = []Mode{ModeMarathon, ModeSprint, ModeUltra}
+
+// Goals of the timed modes.
+const (
+	SprintLines = 40
+	UltraTime   = 2 * time.Minute
+)
+
+// Setup describes how a game starts.
+type Setup struct {
+	Mode     Mode
+	Seed     int64 // Zero deals a new sequence every game
+	Level    int   // Starting level, 1 when zero
+	LineGoal int   // Lines to clear in sprint, SprintLines when zero
+}
+
+// apply starts g, and r if not nil, as described by s.
+func (s Setup) apply(g *GameState, r *Replay) {
+	g.Mode = s.Mode
+	if s.Level > 1 {
+		g.Level = s.Level
+	}
+	g.LineGoal = s.LineGoal
+	if r != nil {
+		r.Mode, r.Level, r.LineGoal = s.Mode, s.Level, s.LineGoal
+	}
+}
+
+// Timed reports whether the mode is played against the clock.
+func (m Mode) Timed() bool {
+	return m == ModeSprint || m == ModeUltra
+}
+
+// concealed reports whether the board is hidden, which it is while a timed
+// game is paused so that the pause cannot be used to plan ahead.
+func (g *GameState) concealed() bool {
+	return g.Paused && !g.GameOver && g.Mode.Timed()
+}
+
+// Goal returns the lines to clear in sprint.
+func (g *GameState) Goal() int {
+	if g.LineGoal > 0 {
+		return g.LineGoal
+	}
+	return SprintLines
+}
+
+// ParseMode returns the mode with the given name.
+func ParseMode(name string) (Mode, error) {
+	for _, m := range Modes {
+		if string(m) == name {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf("unknown mode %q", name)
+}
+
+// checkGoal ends the game once the mode's goal is reached.
+func (g *GameState) checkGoal() {
+	if g.GameOver {
+		return
+	}
+	switch g.Mode {
+	case ModeSprint:
+		g.Completed = g.LinesCleared >= g.Goal()
+	case ModeUltra:
+		g.Completed = g.Elapsed >= UltraTime
+	}
+	if g.Completed {
+		g.end(EndGoal)
+	}
+}
+
+// Ending is why a game ended.
+type Ending int
+
+const (
+	EndNone     Ending = iota // Still playing, or ended from outside the game
+	EndGoal                   // The mode's goal was reached
+	EndBlockOut               // A new piece had no room to appear
+	EndGarbage                // Incoming garbage pushed blocks out of the top
+)
+
+// end ends the game for the given reason, unless it is already over.
+func (g *GameState) end(e Ending) {
+	if g.GameOver {
+		return
+	}
+	g.GameOver = true
+	g.Ending = e
+}
+
+// EndReason describes why the game ended.
+func (g *GameState) EndReason() string {
+	switch g.Ending {
+	case EndGoal:
+		if g.Mode == ModeUltra {
+			return "Time's up"
+		}
+		return fmt.Sprintf("%d lines cleared", g.Goal())
+	case EndBlockOut:
+		return "Topped out: no room for the next piece"
+	case EndGarbage:
+		return "Topped out: pushed up by garbage"
+	}
+	return "Game over"
+}
+
+// FormatTime formats a duration as minutes, seconds and hundredths, as the
+// timed modes show it.
+func FormatTime(d time.Duration) string {
+	cs := int(d.Round(10*time.Millisecond) / (10 * time.Millisecond))
+	return fmt.Sprintf("%d:%02d.%02d", cs/6000, cs/100%60, cs%100)
+}
@@ -0,0 +1,100 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSprint_EndsAtGoal(t *testing.T) {
+	g := NewGameStateWithSeed(1)
+	g.Mode = ModeSprint
+	if err := g.LoadBoard(`
+current: I
+GGGGGG....
+G.GGGGGGGG`); err != nil {
+		t.Fatal(err)
+	}
+	g.LinesCleared = SprintLines - 1
+	g.Apply(ActionDASRight)
+	g.Apply(ActionHardDrop)
+
+	if !g.Completed || !g.GameOver {
+		t.Errorf("Completed = %v, GameOver = %v after line %d, want both", g.Completed, g.GameOver, g.LinesCleared)
+	}
+}
+
+func TestUltra_EndsAfterTimeLimit(t *testing.T) {
+	g := NewGameStateWithSeed(1)
+	g.Mode = ModeUltra
+	frames := 0
+	for !g.GameOver && frames < 10000 {
+		g.ApplyGravity(1.0 / 60.0)
+		// Keep the stack low so only the clock can end the game.
+		g.Board = Board{}
+		frames++
+	}
+
+	if !g.Completed || frames != int(UltraTime.Seconds())*60 {
+		t.Errorf("Completed = %v after %d frames, want true after %d", g.Completed, frames, int(UltraTime.Seconds())*60)
+	}
+}
+
+func TestMarathon_HasNoGoal(t *testing.T) {
+	g := NewGameStateWithSeed(1)
+	g.LinesCleared = 1000
+	g.Elapsed = time.Hour
+	g.checkGoal()
+
+	if g.GameOver {
+		t.Error("Expected marathon to continue")
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	if got := FormatTime(83*time.Second + 456*time.Millisecond); got != "1:23.46" {
+		t.Errorf("FormatTime = %q, want 1:23.46", got)
+	}
+}
+
+func TestSetup_RestartKeepsSetup(t *testing.T) {
+	m := NewModelWithSetup(Setup{Mode: ModeSprint, Seed: 42, Level: 5, LineGoal: 20})
+	first := m.State.CurrentPiece.Name
+	m.apply(ActionHardDrop)
+	m = pressKeys(m, "r")
+
+	if m.State.Seed != 42 || m.State.CurrentPiece.Name != first || m.State.PiecesPlaced != 0 {
+		t.Errorf("Restarted with seed %d and piece %s after %d pieces, want seed 42 and piece %s afresh",
+			m.State.Seed, m.State.CurrentPiece.Name, m.State.PiecesPlaced, first)
+	}
+	if m.State.Mode != ModeSprint || m.State.Level != 5 || m.State.Goal() != 20 {
+		t.Errorf("Restarted %s at level %d to %d lines, want sprint at level 5 to 20 lines", m.State.Mode, m.State.Level, m.State.Goal())
+	}
+	if r := m.Replay; r.Seed != 42 || r.Level != 5 || r.LineGoal != 20 {
+		t.Errorf("Replay = %+v, want the setup recorded", r)
+	}
+}
+
+func TestReplayPlayer_ShowsRecordedGame(t *testing.T) {
+	m := NewModelWithSetup(Setup{Mode: ModeSprint, Seed: 3, Level: 4, LineGoal: 20})
+	for frame := range 600 {
+		if frame%20 == 0 {
+			m.apply([]Action{ActionDASLeft, ActionHardDrop, ActionDASRight, ActionHardDrop}[frame/20%4])
+		}
+		next, _ := m.Update(tickMsg{})
+		m = next.(Model)
+	}
+
+	p := NewReplayPlayer(m.Replay)
+	for range 700 {
+		next, _ := p.Update(tickMsg{})
+		p = next.(ReplayPlayer)
+	}
+
+	if p.Frame != m.Replay.Frames {
+		t.Errorf("Player stopped at frame %d, want %d", p.Frame, m.Replay.Frames)
+	}
+	if p.State.Board != m.State.Board || p.State.Score != m.State.Score || p.State.Level != m.State.Level {
+		t.Errorf("Player ended with score %d at level %d, want %d at level %d",
+			p.State.Score, p.State.Level, m.State.Score, m.State.Level)
+	}
+}
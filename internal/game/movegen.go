@@ -0,0 +1,210 @@
+package game
+
+import (
+	"slices"
+
+	"termino/internal/tetromino"
+	"termino/pkg/consts"
+)
+
+// Placement is a final resting position of a piece together with the shortest
+// input sequence that reaches it from the starting position.
+type Placement struct {
+	X, Y, Rotation int
+	Spin           bool     // The piece rotated into a spot it cannot move out of
+	Inputs         []Action // Ends with ActionHardDrop
+}
+
+// Search space bounds. A piece's 4x4 mask may hang up to three cells off the
+// left, right or top of the board while its blocks stay inside.
+const (
+	genMinX   = -3
+	genMinY   = -3
+	genWidth  = consts.BoardWidth - genMinX
+	genHeight = consts.BoardHeight - genMinY
+	genStates = 4 * genWidth * genHeight
+)
+
+// genMoves are the inputs explored by the generator, cheapest first so that
+// ties prefer plain movement over rotation.
+var genMoves = [...]Action{
+	ActionLeft,
+	ActionRight,
+	ActionSoftDrop,
+	ActionRotateCW,
+	ActionRotateCCW,
+	ActionRotate180,
+}
+
+// genFirstRotation is the index of the first rotation in genMoves.
+const genFirstRotation = 3
+
+type genState struct {
+	x, y, rot int
+}
+
+func (s genState) index() int {
+	return (s.rot*genHeight+s.y-genMinY)*genWidth + s.x - genMinX
+}
+
+// placementKey identifies a placement by the cells it covers, so rotations of
+// symmetric pieces that land on the same cells are reported once.
+type placementKey struct {
+	top  int
+	rows [4]tetromino.Bitmask
+	spin bool
+}
+
+// Placements enumerates every reachable final placement of the current piece
+// from its current position.
+func (g *GameState) Placements() []Placement {
+	return GeneratePlacements(&g.Board, g.CurrentPiece, g.CurrentX, g.CurrentY, g.CurrentRotation)
+}
+
+// GeneratePlacements enumerates every final placement piece can reach from x, y
+// and rotation on board, including soft-drop tucks and SRS kicks, with the
+// shortest input sequence for each. A placement entered by a final rotation
+// into a spot the piece cannot slide or lift out of is reported separately
+// from the same cells reached by movement, since only the former is a spin.
+func GeneratePlacements(board *Board, piece tetromino.Tetromino, x, y, rotation int) []Placement {
+	start := genState{x, y, rotation}
+	if !fits(board, &piece, x, y, rotation) {
+		return nil
+	}
+
+	var (
+		visited [genStates]bool
+		parent  [genStates]int16
+		via     [genStates]uint8 // Index into genMoves
+		dropTo  [genStates]int8  // Landing row - genMinY + 1, or 0 while unknown
+		queue   = make([]genState, 0, 512)
+		seen    = make(map[placementKey]struct{}, 64)
+		result  []Placement
+	)
+
+	// drop returns the row a hard drop from s lands on. Results are shared by
+	// every state in the same column and rotation above the landing row.
+	drop := func(s genState) int {
+		y := s.y
+		for dropTo[genState{s.x, y, s.rot}.index()] == 0 && fits(board, &piece, s.x, y+1, s.rot) {
+			y++
+		}
+		walked := y
+		if known := dropTo[genState{s.x, y, s.rot}.index()]; known != 0 {
+			y = int(known) + genMinY - 1
+		}
+		for fill := s.y; fill <= walked; fill++ {
+			dropTo[genState{s.x, fill, s.rot}.index()] = int8(y - genMinY + 1)
+		}
+		return y
+	}
+
+	// land records the placement made by hard dropping from s, reached by the
+	// inputs leading to from followed by move (if move >= 0).
+	land := func(s, from genState, move int, spin bool) {
+		dropY := drop(s)
+		if spin && (dropY != s.y || fits(board, &piece, s.x-1, s.y, s.rot) ||
+			fits(board, &piece, s.x+1, s.y, s.rot) || fits(board, &piece, s.x, s.y-1, s.rot)) {
+			return
+		}
+
+		key := placementKey{spin: spin}
+		key.top, key.rows = cellRows(&piece, s.x, dropY, s.rot)
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+
+		var inputs []Action
+		if move >= 0 {
+			inputs = append(inputs, genMoves[move])
+		}
+		for i := from.index(); i != start.index(); i = int(parent[i]) {
+			inputs = append(inputs, genMoves[via[i]])
+		}
+		slices.Reverse(inputs)
+		result = append(result, Placement{
+			X:        s.x,
+			Y:        dropY,
+			Rotation: s.rot,
+			Spin:     spin,
+			Inputs:   append(inputs, ActionHardDrop),
+		})
+	}
+
+	visited[start.index()] = true
+	queue = append(queue, start)
+	for head := 0; head < len(queue); head++ {
+		cur := queue[head]
+		land(cur, cur, -1, false)
+
+		for m, move := range genMoves {
+			next, ok := genStep(board, &piece, cur, move)
+			if !ok {
+				continue
+			}
+			if m >= genFirstRotation {
+				land(next, cur, m, true)
+			}
+			if visited[next.index()] {
+				continue
+			}
+			visited[next.index()] = true
+			parent[next.index()] = int16(cur.index())
+			via[next.index()] = uint8(m)
+			queue = append(queue, next)
+		}
+	}
+
+	return result
+}
+
+// cellRows returns the board rows covered by a piece, starting at the topmost occupied row.
+func cellRows(piece *tetromino.Tetromino, x, y, rot int) (top int, rows [4]tetromino.Bitmask) {
+	masks := piece.Masks[rot]
+	first := 0
+	for first < 3 && masks[first] == 0 {
+		first++
+	}
+	for r := first; r < 4; r++ {
+		if x >= 0 {
+			rows[r-first] = masks[r] << x
+		} else {
+			rows[r-first] = masks[r] >> -x
+		}
+	}
+	return y + first, rows
+}
+
+// genStep applies a single input with the same rules as GameState.Apply.
+func genStep(board *Board, piece *tetromino.Tetromino, s genState, move Action) (genState, bool) {
+	switch move {
+	case ActionLeft:
+		s.x--
+		return s, fits(board, piece, s.x, s.y, s.rot)
+	case ActionRight:
+		s.x++
+		return s, fits(board, piece, s.x, s.y, s.rot)
+	case ActionSoftDrop:
+		s.y++
+		return s, fits(board, piece, s.x, s.y, s.rot)
+	case ActionRotateCW:
+		return genRotate(board, piece, s, (s.rot+1)%4)
+	case ActionRotateCCW:
+		return genRotate(board, piece, s, (s.rot+3)%4)
+	case ActionRotate180:
+		// Mirrors GameState.Apply, which rotates clockwise twice.
+		first, ok1 := genRotate(board, piece, s, (s.rot+1)%4)
+		second, ok2 := genRotate(board, piece, first, (first.rot+1)%4)
+		return second, ok1 || ok2
+	}
+	return s, false
+}
+
+func genRotate(board *Board, piece *tetromino.Tetromino, s genState, to int) (genState, bool) {
+	x, y, ok := kick(board, piece, s.x, s.y, s.rot, to)
+	if !ok {
+		return s, false
+	}
+	return genState{x, y, to}, true
+}
@@ -0,0 +1,98 @@
+package game
+
+import (
+	"testing"
+
+	"termino/internal/tetromino"
+)
+
+func TestGeneratePlacements_EmptyBoard(t *testing.T) {
+	// Distinct landing positions on an empty 10-wide board.
+	want := map[string]int{"I": 17, "J": 34, "L": 34, "O": 9, "S": 17, "T": 34, "Z": 17}
+
+	for name, count := range want {
+		state := NewGameStateWithSeed(1)
+		state.SpawnPiece(tetromino.NewTetromino(name))
+
+		placements := state.Placements()
+		if len(placements) != count {
+			t.Errorf("%s: expected %d placements, got %d", name, count, len(placements))
+		}
+		for _, p := range placements {
+			if p.Spin {
+				t.Errorf("%s: unexpected spin placement %+v on an empty board", name, p)
+			}
+		}
+	}
+}
+
+func TestGeneratePlacements_InputsReachPlacement(t *testing.T) {
+	state := NewGameStateWithSeed(1)
+	if err := state.LoadBoard(`
+current: T
+GG........
+G...GGGGGG
+GG.GGGGGGG`); err != nil {
+		t.Fatalf("LoadBoard failed: %v", err)
+	}
+
+	for _, p := range state.Placements() {
+		replay := state
+		for _, a := range p.Inputs[:len(p.Inputs)-1] {
+			replay.Apply(a)
+		}
+		for replay.canPlace(replay.CurrentPiece.Name, replay.CurrentX, replay.CurrentY+1, replay.CurrentRotation) {
+			replay.CurrentY++
+		}
+		got := pieceRows(replay.CurrentPiece, replay.CurrentX, replay.CurrentY, replay.CurrentRotation)
+		want := pieceRows(state.CurrentPiece, p.X, p.Y, p.Rotation)
+		if got != want {
+			t.Errorf("Inputs %v do not reach placement (%d, %d) rot %d", p.Inputs, p.X, p.Y, p.Rotation)
+		}
+	}
+}
+
+func TestGeneratePlacements_TSpinSlot(t *testing.T) {
+	state := NewGameStateWithSeed(1)
+	if err := state.LoadBoard(`
+current: T
+GG........
+G...GGGGGG
+GG.GGGGGGG`); err != nil {
+		t.Fatalf("LoadBoard failed: %v", err)
+	}
+
+	var slot *Placement
+	placements := state.Placements()
+	for i, p := range placements {
+		if p.Rotation == 2 && p.X == 1 && p.Y == 37 && p.Spin {
+			slot = &placements[i]
+		}
+	}
+	if slot == nil {
+		t.Fatalf("Expected a T-spin placement into the slot, got %+v", placements)
+	}
+
+	// The slot is only reachable by soft dropping under the overhang and rotating in.
+	last := slot.Inputs[len(slot.Inputs)-2]
+	if last != ActionRotateCW && last != ActionRotateCCW && last != ActionRotate180 {
+		t.Errorf("Expected the slot to be entered by rotation, got %v", slot.Inputs)
+	}
+}
+
+func BenchmarkGeneratePlacements(b *testing.B) {
+	state := NewGameStateWithSeed(1)
+	if err := state.LoadBoard(`
+current: T
+.......GG.
+G......GGG
+GG.GGGGGGG
+GGGG.GGGGG`); err != nil {
+		b.Fatalf("LoadBoard failed: %v", err)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		state.Placements()
+	}
+}
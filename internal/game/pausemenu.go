@@ -0,0 +1,99 @@
+package game
+
+import (
+	"termino/internal/render"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Frames of the countdown shown before play starts or resumes: READY, then
+// GO. The clock does not run during it.
+const (
+	readyFrames     = 60
+	goFrames        = 30
+	countdownFrames = readyFrames + goFrames
+)
+
+// pauseItem is an entry of the pause menu.
+type pauseItem int
+
+const (
+	pauseResume pauseItem = iota
+	pauseRestart
+	pauseSettings
+	pauseQuit
+	numPauseItems
+)
+
+var pauseLabels = [numPauseItems]string{"Resume", "Restart", "Settings", "Quit"}
+
+// pause stops the game and shows the pause menu.
+func (m *Model) pause() {
+	m.State.Paused = true
+	m.pauseCursor = pauseResume
+}
+
+// resume closes the pause menu and counts down before play continues.
+func (m *Model) resume() {
+	m.State.Paused = false
+	m.countdown = countdownFrames
+}
+
+// updatePause handles a key while the pause menu is shown. It returns false
+// for keys the menu leaves to the game's own controls.
+func (m *Model) updatePause(key tea.KeyMsg) (tea.Cmd, bool) {
+	switch key.String() {
+	case "up":
+		m.pauseCursor = (m.pauseCursor + numPauseItems - 1) % numPauseItems
+	case "down":
+		m.pauseCursor = (m.pauseCursor + 1) % numPauseItems
+	case "p", "esc":
+		m.resume()
+	case "enter":
+		switch m.pauseCursor {
+		case pauseResume:
+			m.resume()
+		case pauseRestart:
+			m.restart()
+		case pauseSettings:
+			m.openSettings()
+		case pauseQuit:
+			m.saveProgress()
+			return tea.Quit, true
+		}
+	default:
+		return nil, false
+	}
+	return nil, true
+}
+
+// drawPause draws the pause menu over the board at x, y.
+func (m *Model) drawPause(b *render.Buffer, x, y int) {
+	for row := range int(numPauseItems) + 3 {
+		for col := range 20 {
+			b.Set(x+1+col, y+7+row, ' ', lipgloss.NewStyle())
+		}
+	}
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	selected := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFF00")).Bold(true)
+	writeString(b, x+8, y+7, "PAUSED", selected)
+	for i, label := range pauseLabels {
+		if pauseItem(i) == m.pauseCursor {
+			writeString(b, x+5, y+9+i, "> "+label, selected)
+		} else {
+			writeString(b, x+5, y+9+i, "  "+label, style)
+		}
+	}
+}
+
+// drawCountdown draws READY or GO over the board at x, y while the countdown
+// runs.
+func (m *Model) drawCountdown(b *render.Buffer, x, y int) {
+	switch {
+	case m.countdown > goFrames:
+		writeString(b, x+8, y+9, "READY", lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFF00")).Bold(true))
+	case m.countdown > 0:
+		writeString(b, x+9, y+9, "GO!", lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true))
+	}
+}
@@ -0,0 +1,75 @@
+package game
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func tick(m Model, n int) Model {
+	for range n {
+		next, _ := m.Update(tickMsg{})
+		m = next.(Model)
+	}
+	return m
+}
+
+func TestCountdown_HoldsPlayAndClock(t *testing.T) {
+	m := NewModelWithMode(1, ModeSprint)
+	x := m.State.CurrentX
+	m = pressKeys(m, "left")
+	m = tick(m, countdownFrames)
+
+	if m.Frame != 0 || m.State.Elapsed != 0 || m.State.CurrentX != x {
+		t.Fatalf("During the countdown: frame %d, elapsed %v, piece moved to %d", m.Frame, m.State.Elapsed, m.State.CurrentX)
+	}
+	m = pressKeys(m, "left")
+	m = tick(m, 1)
+	if m.Frame != 1 || m.State.CurrentX != x-1 {
+		t.Errorf("After the countdown: frame %d, piece at %d, want 1 and %d", m.Frame, m.State.CurrentX, x-1)
+	}
+}
+
+func TestPauseMenu(t *testing.T) {
+	m := tick(NewModelWithMode(1, ModeUltra), countdownFrames+10)
+
+	m = pressKeys(m, "p")
+	if !m.State.Paused || !m.State.concealed() {
+		t.Fatalf("Paused = %v, concealed = %v after 'p', want both", m.State.Paused, m.State.concealed())
+	}
+	if m = tick(m, 10); m.Frame != 10 {
+		t.Errorf("Frame = %d while paused, want 10", m.Frame)
+	}
+
+	// Resume counts down again.
+	m = pressKeys(m, "enter")
+	if m.State.Paused || m.countdown != countdownFrames {
+		t.Errorf("Paused = %v, countdown %d after resuming", m.State.Paused, m.countdown)
+	}
+
+	// Restart is the second item.
+	m = pressKeys(tick(m, countdownFrames+10), "p", "down", "enter")
+	if m.State.Paused || m.Frame != 0 || m.State.Mode != ModeUltra {
+		t.Errorf("After restarting: paused %v, frame %d, mode %s", m.State.Paused, m.Frame, m.State.Mode)
+	}
+
+	// Quit is the last.
+	m = pressKeys(m, "p", "up")
+	if _, cmd := m.Update(key("enter")); cmd == nil {
+		t.Error("Quit did not quit")
+	} else if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("Quit did not quit")
+	}
+}
+
+func TestPauseMenu_Settings(t *testing.T) {
+	m := tick(NewModelWithSeed(1), countdownFrames)
+	m = pressKeys(m, "p", "down", "down", "enter")
+	if m.menu == nil {
+		t.Fatal("Settings did not open the settings menu")
+	}
+	m = pressKeys(m, "esc")
+	if m.menu != nil || !m.State.Paused {
+		t.Errorf("After leaving the settings: menu open %v, paused %v, want the pause menu back", m.menu != nil, m.State.Paused)
+	}
+}
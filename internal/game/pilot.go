@@ -0,0 +1,155 @@
+package game
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Pilot lets an advisor play a game through the same inputs as the keyboard.
+type Pilot struct {
+	Bot      Advisor
+	Interval int // Frames between inputs
+	Pace     int // Minimum frames from one hard drop to the next piece, capping pieces per second
+}
+
+// Autopilot plays a game with a pilot's bot outside of a Model or Versus.
+type Autopilot struct {
+	Pilot Pilot
+	state autopilot
+}
+
+// Step performs the bot's input for the current frame, if any. Call it once
+// per frame before gravity.
+func (a *Autopilot) Step(g *GameState) {
+	a.state.step(g, a.Pilot, g.Apply)
+}
+
+// autopilot performs an advisor's moves one input at a time.
+type autopilot struct {
+	target    *Suggestion
+	piece     int      // PiecesPlaced when target was chosen
+	plan      []Action // Remaining inputs towards target
+	expect    [3]int   // Piece X, Y and rotation the plan continues from
+	wait      int
+	sinceDrop int        // Frames since the last hard drop
+	asked     *GameState // Snapshot an asynchronous suggestion was requested for
+}
+
+// suggestionMsg delivers a suggestion requested by stepAsync.
+type suggestionMsg struct {
+	state      *GameState // Snapshot the bot was asked about
+	suggestion Suggestion
+	ok         bool
+}
+
+// step is called once per frame and performs the bot's next input, if any.
+// The plan is recomputed whenever gravity has moved the piece away from where
+// the plan expects it.
+func (p *autopilot) step(g *GameState, pilot Pilot, apply func(Action)) {
+	if !p.ready(g, pilot) {
+		return
+	}
+	if p.target == nil || p.piece != g.PiecesPlaced {
+		s, ok := pilot.Bot.Suggest(g)
+		if !ok {
+			p.wait = pilot.Interval
+			return
+		}
+		p.choose(g, s)
+	}
+	p.move(g, pilot, apply)
+}
+
+// stepAsync is step for bots that may take longer than a frame to answer.
+// Instead of waiting for the bot it returns a command that asks it about a
+// snapshot of g, and the game keeps running until deliver receives the answer.
+func (p *autopilot) stepAsync(g *GameState, pilot Pilot, apply func(Action)) tea.Cmd {
+	if !p.ready(g, pilot) {
+		return nil
+	}
+	if p.target == nil || p.piece != g.PiecesPlaced {
+		if p.asked != nil {
+			return nil
+		}
+		snapshot := g.snapshot()
+		p.asked = snapshot
+		return func() tea.Msg {
+			s, ok := pilot.Bot.Suggest(snapshot)
+			return suggestionMsg{state: snapshot, suggestion: s, ok: ok}
+		}
+	}
+	p.move(g, pilot, apply)
+	return nil
+}
+
+// snapshot returns a copy of g that a command can read while g keeps changing.
+func (g *GameState) snapshot() *GameState {
+	s := *g
+	s.NextQueue = slices.Clone(g.NextQueue)
+	s.PendingGarbage = slices.Clone(g.PendingGarbage)
+	return &s
+}
+
+// deliver accepts the answer to the last stepAsync request. Answers to
+// earlier requests, or about a piece that has since locked, are dropped.
+func (p *autopilot) deliver(g *GameState, pilot Pilot, msg suggestionMsg) {
+	if msg.state != p.asked {
+		return
+	}
+	p.asked = nil
+	switch {
+	case !msg.ok:
+		p.wait = pilot.Interval
+	case msg.state.PiecesPlaced == g.PiecesPlaced:
+		p.choose(g, msg.suggestion)
+	}
+}
+
+// ready counts the frame and reports whether the bot may act on it.
+func (p *autopilot) ready(g *GameState, pilot Pilot) bool {
+	p.sinceDrop++
+	if p.wait > 0 {
+		p.wait--
+		return false
+	}
+	return (p.target != nil && p.piece == g.PiecesPlaced) || p.sinceDrop >= pilot.Pace
+}
+
+// choose makes s the target for the current piece.
+func (p *autopilot) choose(g *GameState, s Suggestion) {
+	p.target = &s
+	p.piece = g.PiecesPlaced
+	p.plan = nil
+}
+
+// move performs the next input towards the target.
+func (p *autopilot) move(g *GameState, pilot Pilot, apply func(Action)) {
+	p.wait = pilot.Interval
+
+	if p.target.Hold {
+		p.target.Hold = false
+		apply(ActionHold)
+		return
+	}
+
+	pos := [3]int{g.CurrentX, g.CurrentY, g.CurrentRotation}
+	if len(p.plan) == 0 || pos != p.expect {
+		plan, ok := g.PathTo(p.target.Placement)
+		if !ok {
+			// The placement became unreachable; choose again next time.
+			p.target = nil
+			return
+		}
+		p.plan = plan
+	}
+
+	action := p.plan[0]
+	p.plan = p.plan[1:]
+	if action == ActionHardDrop {
+		p.target = nil
+		p.sinceDrop = 0
+	}
+	apply(action)
+	p.expect = [3]int{g.CurrentX, g.CurrentY, g.CurrentRotation}
+}
@@ -0,0 +1,133 @@
+package game
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// countAdvisor answers like dropAdvisor and counts how often it was asked.
+type countAdvisor struct {
+	calls *int
+}
+
+func (a countAdvisor) Suggest(g *GameState) (Suggestion, bool) {
+	*a.calls++
+	return dropAdvisor{}.Suggest(g)
+}
+
+// answer runs the commands batched in cmd until one returns a T.
+func answer[T tea.Msg](t *testing.T, cmd tea.Cmd) T {
+	t.Helper()
+	var zero T
+	if cmd == nil {
+		t.Fatal("Expected a command")
+	}
+	cmds := []tea.Cmd{cmd}
+	if batch, ok := cmd().(tea.BatchMsg); ok {
+		cmds = batch
+	}
+	for _, c := range cmds {
+		if c == nil {
+			continue
+		}
+		if msg, ok := c().(T); ok {
+			return msg
+		}
+	}
+	t.Fatalf("Expected a %T", zero)
+	return zero
+}
+
+// suggestion runs the commands batched in cmd until one answers for the bot.
+func suggestion(t *testing.T, cmd tea.Cmd) suggestionMsg {
+	t.Helper()
+	return answer[suggestionMsg](t, cmd)
+}
+
+func TestModelBot_KeepsTickingWhileThinking(t *testing.T) {
+	m := NewModelWithSeed(1)
+	calls := 0
+	m.Bot = countAdvisor{&calls}
+	m = tick(m, countdownFrames)
+
+	next, cmd := m.Update(tickMsg{})
+	m = next.(Model)
+	m = tick(m, 30)
+	if calls != 0 || m.Frame != 31 || m.State.PiecesPlaced != 0 {
+		t.Fatalf("While the bot thinks: %d calls, frame %d, %d pieces placed", calls, m.Frame, m.State.PiecesPlaced)
+	}
+
+	next, _ = m.Update(suggestion(t, cmd))
+	m = next.(Model)
+	m = tick(m, 20)
+	if calls != 1 || m.State.PiecesPlaced != 1 {
+		t.Errorf("Expected one suggestion to be played, got %d calls and %d pieces placed", calls, m.State.PiecesPlaced)
+	}
+}
+
+func TestModelBot_DropsStaleSuggestion(t *testing.T) {
+	m := NewModelWithSeed(1)
+	m.Bot = dropAdvisor{}
+	m = tick(m, countdownFrames)
+
+	next, cmd := m.Update(tickMsg{})
+	m = next.(Model)
+	msg := suggestion(t, cmd)
+
+	m.restart()
+	m = tick(m, countdownFrames)
+	next, _ = m.Update(msg)
+	m = next.(Model)
+	if m.pilot.target != nil {
+		t.Errorf("Expected a suggestion from the previous game to be dropped, got %+v", *m.pilot.target)
+	}
+}
+
+func TestModelHint_AskedFromCommand(t *testing.T) {
+	m := NewModelWithSeed(1)
+	calls := 0
+	m.Hints = countAdvisor{&calls}
+	m.ShowHint = true
+	m = tick(m, countdownFrames)
+
+	next, cmd := m.Update(tickMsg{})
+	m = next.(Model)
+	m = tick(m, 5)
+	if calls != 0 || m.hint != nil {
+		t.Fatalf("Expected no hint before the command runs, got %d calls", calls)
+	}
+
+	next, _ = m.Update(answer[hintMsg](t, cmd))
+	m = next.(Model)
+	if calls != 1 || m.hint == nil {
+		t.Errorf("Expected the answer to be shown, got %d calls and hint %v", calls, m.hint)
+	}
+}
+
+func TestModelHint_DropsStaleAnswer(t *testing.T) {
+	m := NewModelWithSeed(1)
+	m.Hints = dropAdvisor{}
+	m.ShowHint = true
+	m = tick(m, countdownFrames)
+
+	next, cmd := m.Update(tickMsg{})
+	m = next.(Model)
+	msg := answer[hintMsg](t, cmd)
+
+	m = pressKeys(m, "z")
+	next, _ = m.Update(msg)
+	m = next.(Model)
+	if m.hint != nil {
+		t.Errorf("Expected a hint for the piece before hold to be dropped, got %+v", *m.hint)
+	}
+
+	// The next tick asks again for the held position.
+	next, cmd = m.Update(tickMsg{})
+	m = next.(Model)
+	next, _ = m.Update(answer[hintMsg](t, cmd))
+	m = next.(Model)
+	if m.hint == nil {
+		t.Error("Expected a hint for the position after hold")
+	}
+}
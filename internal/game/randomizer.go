@@ -1,7 +1,9 @@
 package game
 
 import (
+	"fmt"
 	"math/rand"
+	"strings"
 	"time"
 
 	"termino/internal/tetromino"
@@ -11,12 +13,17 @@ type Randomizer struct {
 	currentBag []tetromino.Tetromino
 	nextBag    []tetromino.Tetromino
 	rng        *rand.Rand
+	src        *countingSource
 }
 
 func NewRandomizer() *Randomizer {
-	r := &Randomizer{
-		rng: rand.New(rand.NewSource(time.Now().UnixNano())),
-	}
+	return NewRandomizerWithSeed(time.Now().UnixNano())
+}
+
+// NewRandomizerWithSeed creates a 7-bag randomizer that deals the same sequence for the same seed.
+func NewRandomizerWithSeed(seed int64) *Randomizer {
+	r := &Randomizer{}
+	r.rng, r.src = newCountedRand(seed, 0)
 	r.currentBag = r.createNewBag()
 	r.nextBag = r.createNewBag()
 	return r
@@ -51,3 +58,90 @@ func (r *Randomizer) createNewBag() []tetromino.Tetromino {
 
 	return bag
 }
+
+// RandomizerState is everything needed to restore a randomizer: its seed, how
+// far its generator has advanced and the pieces left in its bags.
+type RandomizerState struct {
+	Seed    int64  `json:"seed"`
+	Draws   uint64 `json:"draws"`
+	Current string `json:"current"`
+	Next    string `json:"next"`
+}
+
+// State returns the randomizer's state.
+func (r *Randomizer) State() RandomizerState {
+	return RandomizerState{
+		Seed:    r.src.seed,
+		Draws:   r.src.draws,
+		Current: pieceNames(r.currentBag),
+		Next:    pieceNames(r.nextBag),
+	}
+}
+
+// RestoreRandomizer creates a randomizer that deals the same pieces as the one
+// s was taken from.
+func RestoreRandomizer(s RandomizerState) (*Randomizer, error) {
+	r := &Randomizer{}
+	r.rng, r.src = newCountedRand(s.Seed, s.Draws)
+	var err error
+	if r.currentBag, err = parsePieces(s.Current); err != nil {
+		return nil, err
+	}
+	if r.nextBag, err = parsePieces(s.Next); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// countingSource is a math/rand source that counts its draws, so that its
+// state can be saved as the seed and the number of draws and restored by
+// replaying them.
+type countingSource struct {
+	rand.Source64
+	seed  int64
+	draws uint64
+}
+
+// newCountedRand returns a generator seeded with seed that has already made
+// draws draws, and its source.
+func newCountedRand(seed int64, draws uint64) (*rand.Rand, *countingSource) {
+	src := &countingSource{Source64: rand.NewSource(seed).(rand.Source64), seed: seed}
+	for range draws {
+		src.Uint64()
+	}
+	return rand.New(src), src
+}
+
+func (s *countingSource) Int63() int64 {
+	s.draws++
+	return s.Source64.Int63()
+}
+
+func (s *countingSource) Uint64() uint64 {
+	s.draws++
+	return s.Source64.Uint64()
+}
+
+func (s *countingSource) Seed(seed int64) {
+	s.Source64.Seed(seed)
+	s.seed, s.draws = seed, 0
+}
+
+func pieceNames(pieces []tetromino.Tetromino) string {
+	var sb strings.Builder
+	for _, p := range pieces {
+		sb.WriteString(p.Name)
+	}
+	return sb.String()
+}
+
+func parsePieces(names string) ([]tetromino.Tetromino, error) {
+	pieces := make([]tetromino.Tetromino, 0, len(names))
+	for _, r := range names {
+		if !isPieceName(r) {
+			return nil, fmt.Errorf("unknown piece %q", r)
+		}
+		pieces = append(pieces, tetromino.NewTetromino(string(r)))
+	}
+	return pieces, nil
+}
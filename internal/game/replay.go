@@ -0,0 +1,115 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+const replayVersion = 1
+
+// ReplayEvent is a single action applied before the gravity tick of Frame.
+type ReplayEvent struct {
+	Frame  int    `json:"frame"`
+	Action Action `json:"action"`
+}
+
+// Replay records the seed and input stream of a game. Because the engine is
+// deterministic for a given seed, replaying the events reproduces every frame.
+type Replay struct {
+	Version   int           `json:"version"`
+	Seed      int64         `json:"seed"`
+	Mode      Mode          `json:"mode,omitempty"`
+	Level     int           `json:"level,omitempty"`      // Starting level, when not 1
+	LineGoal  int           `json:"line_goal,omitempty"`  // Sprint goal, when not SprintLines
+	LockDelay time.Duration `json:"lock_delay,omitempty"` // Zero for the default
+	Frames    int           `json:"frames"`
+	Events    []ReplayEvent `json:"events"`
+}
+
+// NewReplay creates an empty replay for a game started with seed.
+func NewReplay(seed int64) *Replay {
+	return &Replay{Version: replayVersion, Seed: seed}
+}
+
+// Record appends an action applied during the given frame.
+func (r *Replay) Record(frame int, a Action) {
+	r.Events = append(r.Events, ReplayEvent{Frame: frame, Action: a})
+}
+
+// Play re-simulates the replay, calling fn with the state after every frame.
+// Frame 0 is the freshly spawned game. Playback stops early if fn returns false.
+func (r *Replay) Play(fn func(frame int, g *GameState) bool) {
+	g := r.start()
+	if !fn(0, &g) {
+		return
+	}
+
+	next := 0
+	for frame := 0; frame < r.Frames; frame++ {
+		r.step(&g, frame, &next)
+		if !fn(frame+1, &g) {
+			return
+		}
+	}
+}
+
+// start returns the freshly spawned game the replay begins with.
+func (r *Replay) start() GameState {
+	g := NewGameStateWithSeed(r.Seed)
+	Setup{Mode: r.Mode, Level: r.Level, LineGoal: r.LineGoal}.apply(&g, nil)
+	if r.LockDelay > 0 {
+		g.LockDelay = r.LockDelay
+	}
+	return g
+}
+
+// step applies the events of frame, starting at index next, and its gravity
+// tick to g.
+func (r *Replay) step(g *GameState, frame int, next *int) {
+	for *next < len(r.Events) && r.Events[*next].Frame == frame {
+		if !g.GameOver {
+			g.Apply(r.Events[*next].Action)
+		}
+		*next++
+	}
+	if !g.GameOver {
+		g.ApplyGravity(1.0 / 60.0)
+	}
+}
+
+// StateAt returns the game state after the given number of frames.
+func (r *Replay) StateAt(frame int) GameState {
+	var out GameState
+	r.Play(func(f int, g *GameState) bool {
+		out = *g
+		return f < frame
+	})
+	return out
+}
+
+// Save writes the replay as JSON to path.
+func (r *Replay) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// LoadReplay reads a replay previously written by Save.
+func LoadReplay(path string) (*Replay, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r Replay
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("parse replay %s: %w", path, err)
+	}
+	if r.Version != replayVersion {
+		return nil, fmt.Errorf("replay %s: unsupported version %d", path, r.Version)
+	}
+	return &r, nil
+}
@@ -0,0 +1,73 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"termino/internal/settings"
+	"termino/pkg/consts"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ReplayPlayer plays a replay back at the speed it was recorded.
+type ReplayPlayer struct {
+	Replay *Replay
+	State  GameState
+	Width  int
+	Height int
+	Frame  int  // Frames shown so far
+	Paused bool // Toggled with 'p'
+	next   int  // Index of the next event to apply
+}
+
+// NewReplayPlayer shows r from its first frame.
+func NewReplayPlayer(r *Replay) ReplayPlayer {
+	return ReplayPlayer{Replay: r, State: r.start(), Width: 80, Height: 24}
+}
+
+func (p ReplayPlayer) Init() tea.Cmd {
+	return tea.Tick(time.Second/60, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
+
+func (p ReplayPlayer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.Width, p.Height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return p, tea.Quit
+		case "p", " ":
+			p.Paused = !p.Paused
+		case "r":
+			p.State, p.Frame, p.next = p.Replay.start(), 0, 0
+		}
+	case tickMsg:
+		if !p.Paused && p.Frame < p.Replay.Frames {
+			p.Replay.step(&p.State, p.Frame, &p.next)
+			p.Frame++
+		}
+		return p, p.Init()
+	}
+	return p, nil
+}
+
+func (p ReplayPlayer) View() string {
+	x, y := drawGame(screen(), &p.State, nil, settings.Default(), p.Width, p.Height)
+	status := "REPLAY"
+	switch {
+	case p.Frame >= p.Replay.Frames:
+		status = "END"
+	case p.Paused:
+		status = "PAUSED"
+	}
+	total := time.Duration(p.Replay.Frames) * time.Second / 60
+	shown := time.Duration(p.Frame) * time.Second / 60
+	line := fmt.Sprintf("%-7s %s / %s   p pause   r restart   q back", status, FormatTime(shown), FormatTime(total))
+	writeString(ScreenBuffer, x-10, y+consts.VisibleHeight+2, line, lipgloss.NewStyle().Foreground(lipgloss.Color("#888888")))
+	return ScreenBuffer.Render()
+}
@@ -0,0 +1,323 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"termino/internal/settings"
+	"termino/internal/tetromino"
+	"termino/pkg/consts"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const saveVersion = 1
+
+// ErrSaveVersion is returned when restoring a game saved by an incompatible
+// version of termino.
+var ErrSaveVersion = errors.New("game: unsupported save version")
+
+// SavedGame is an in-progress game written to disk when the player quits, from
+// which the game continues exactly where it was left.
+type SavedGame struct {
+	Version int       `json:"version"`
+	Date    time.Time `json:"date"`
+	Frame   int       `json:"frame"`
+	Replay  *Replay   `json:"replay,omitempty"`
+
+	Board      []string        `json:"board"` // Rows in the board text format, top first, down to the bottom row
+	Piece      string          `json:"piece"`
+	X          int             `json:"x"`
+	Y          int             `json:"y"`
+	Rotation   int             `json:"rotation"`
+	Hold       string          `json:"hold,omitempty"`
+	HoldUsed   bool            `json:"hold_used,omitempty"`
+	Queue      string          `json:"queue"`
+	Randomizer RandomizerState `json:"randomizer"`
+	Seed       int64           `json:"seed"`
+
+	Score        int   `json:"score"`
+	Level        int   `json:"level"`
+	Lines        int   `json:"lines"`
+	BackToBack   bool  `json:"back_to_back,omitempty"`
+	Combo        int   `json:"combo"`
+	PiecesPlaced int   `json:"pieces_placed"`
+	LastClear    Clear `json:"last_clear"`
+	Stats        Stats `json:"stats"`
+
+	PendingGarbage []int   `json:"pending_garbage,omitempty"`
+	AttackSent     int     `json:"attack_sent"`
+	Messiness      float64 `json:"messiness,omitempty"`
+	GarbageDraws   uint64  `json:"garbage_draws"`
+
+	LastRotated        bool          `json:"last_rotated,omitempty"`
+	LastKick           int           `json:"last_kick"`
+	LockDelay          time.Duration `json:"lock_delay"`
+	LockTimer          time.Duration `json:"lock_timer"`
+	LockResets         int           `json:"lock_resets"`
+	GravityAccumulator float64       `json:"gravity_accumulator"`
+
+	PieceInputs      int    `json:"piece_inputs"`
+	FinessePieces    int    `json:"finesse_pieces"`
+	FinesseFaults    int    `json:"finesse_faults"`
+	InputRun         Action `json:"input_run,omitempty"`
+	InputRunStart    int    `json:"input_run_start"`
+	InputRunLen      int    `json:"input_run_len"`
+	PieceSoftDropped bool   `json:"piece_soft_dropped,omitempty"`
+
+	Mode     Mode          `json:"mode,omitempty"`
+	LineGoal int           `json:"line_goal,omitempty"`
+	Elapsed  time.Duration `json:"elapsed"`
+}
+
+// Save captures the game in progress.
+func (m *Model) Save() SavedGame {
+	g := &m.State
+	s := SavedGame{
+		Version:    saveVersion,
+		Date:       time.Now(),
+		Frame:      m.Frame,
+		Replay:     m.Replay,
+		Board:      formatRows(g),
+		Piece:      g.CurrentPiece.Name,
+		X:          g.CurrentX,
+		Y:          g.CurrentY,
+		Rotation:   g.CurrentRotation,
+		HoldUsed:   g.HoldUsed,
+		Queue:      pieceNames(g.NextQueue),
+		Randomizer: g.Randomizer.State(),
+		Seed:       g.Seed,
+
+		Score:        g.Score,
+		Level:        g.Level,
+		Lines:        g.LinesCleared,
+		BackToBack:   g.BackToBack,
+		Combo:        g.Combo,
+		PiecesPlaced: g.PiecesPlaced,
+		LastClear:    g.LastClear,
+		Stats:        g.Stats,
+
+		PendingGarbage: g.PendingGarbage,
+		AttackSent:     g.AttackSent,
+		Messiness:      g.Messiness,
+
+		LastRotated:        g.lastRotated,
+		LastKick:           g.lastKick,
+		LockDelay:          g.LockDelay,
+		LockTimer:          g.LockTimer,
+		LockResets:         g.LockResets,
+		GravityAccumulator: g.GravityAccumulator,
+
+		PieceInputs:      g.PieceInputs,
+		FinessePieces:    g.FinessePieces,
+		FinesseFaults:    g.FinesseFaults,
+		InputRun:         g.inputRun,
+		InputRunStart:    g.inputRunStart,
+		InputRunLen:      g.inputRunLen,
+		PieceSoftDropped: g.pieceSoftDropped,
+
+		Mode:     g.Mode,
+		LineGoal: g.LineGoal,
+		Elapsed:  g.Elapsed,
+	}
+	if g.HoldPiece != nil {
+		s.Hold = g.HoldPiece.Name
+	}
+	if g.garbageSrc != nil {
+		s.GarbageDraws = g.garbageSrc.draws
+	}
+	return s
+}
+
+// NewModelFromSave restores a saved game. It starts paused, so that the
+// player can get ready before it continues.
+func NewModelFromSave(s SavedGame) (Model, error) {
+	if s.Version != saveVersion {
+		return Model{}, fmt.Errorf("%w %d", ErrSaveVersion, s.Version)
+	}
+	r, err := RestoreRandomizer(s.Randomizer)
+	if err != nil {
+		return Model{}, fmt.Errorf("randomizer: %w", err)
+	}
+	queue, err := parsePieces(s.Queue)
+	if err != nil {
+		return Model{}, fmt.Errorf("queue: %w", err)
+	}
+	if len(s.Piece) != 1 || !isPieceName(rune(s.Piece[0])) {
+		return Model{}, fmt.Errorf("unknown current piece %q", s.Piece)
+	}
+
+	g := GameState{
+		CurrentPiece:    tetromino.NewTetromino(s.Piece),
+		CurrentX:        s.X,
+		CurrentY:        s.Y,
+		CurrentRotation: s.Rotation,
+		HoldUsed:        s.HoldUsed,
+		NextQueue:       queue,
+		Randomizer:      r,
+		Seed:            s.Seed,
+
+		Score:        s.Score,
+		Level:        s.Level,
+		LinesCleared: s.Lines,
+		BackToBack:   s.BackToBack,
+		Combo:        s.Combo,
+		PiecesPlaced: s.PiecesPlaced,
+		LastClear:    s.LastClear,
+		Stats:        s.Stats,
+
+		PendingGarbage: s.PendingGarbage,
+		AttackSent:     s.AttackSent,
+		Messiness:      s.Messiness,
+
+		lastRotated: s.LastRotated,
+		lastKick:    s.LastKick,
+
+		LockDelay:          s.LockDelay,
+		LockTimer:          s.LockTimer,
+		LockResets:         s.LockResets,
+		GravityAccumulator: s.GravityAccumulator,
+
+		PieceInputs:      s.PieceInputs,
+		FinessePieces:    s.FinessePieces,
+		FinesseFaults:    s.FinesseFaults,
+		inputRun:         s.InputRun,
+		inputRunStart:    s.InputRunStart,
+		inputRunLen:      s.InputRunLen,
+		pieceSoftDropped: s.PieceSoftDropped,
+
+		Mode:     s.Mode,
+		LineGoal: s.LineGoal,
+		Elapsed:  s.Elapsed,
+		Paused:   true,
+	}
+	if err := parseRows(&g, s.Board); err != nil {
+		return Model{}, err
+	}
+	if s.Hold != "" {
+		if !isPieceName(rune(s.Hold[0])) {
+			return Model{}, fmt.Errorf("unknown hold piece %q", s.Hold)
+		}
+		piece := tetromino.NewTetromino(s.Hold[:1])
+		g.HoldPiece = &piece
+	}
+	if g.LockDelay <= 0 {
+		g.LockDelay = settings.DefaultLockDelay * time.Millisecond
+	}
+	g.garbageRand, g.garbageSrc = newCountedRand(garbageSeed(s.Seed), s.GarbageDraws)
+	g.UpdateGhost()
+
+	setup := Setup{Mode: s.Mode, LineGoal: s.LineGoal}
+	if s.Replay != nil {
+		setup.Level = s.Replay.Level
+	}
+	return Model{
+		State:    g,
+		Width:    80,
+		Height:   24,
+		Frame:    s.Frame,
+		Replay:   s.Replay,
+		Settings: settings.Default(),
+		setup:    setup,
+	}, nil
+}
+
+// formatRows writes the locked cells of the board as rows of the board text
+// format, from the highest non-empty row down.
+func formatRows(g *GameState) []string {
+	var rows []string
+	for row := range consts.BoardHeight {
+		if g.Board[row] == 0 && rows == nil {
+			continue
+		}
+		var sb strings.Builder
+		for x := range consts.BoardWidth {
+			if g.Board[row]&tetromino.Bitmask(1<<x) != 0 {
+				sb.WriteRune(cellRune(g.BoardColors[row][x]))
+			} else {
+				sb.WriteRune(emptyCell)
+			}
+		}
+		rows = append(rows, sb.String())
+	}
+	return rows
+}
+
+// parseRows fills the board from rows written by formatRows.
+func parseRows(g *GameState, rows []string) error {
+	if len(rows) > consts.BoardHeight {
+		return fmt.Errorf("board has %d rows, at most %d are allowed", len(rows), consts.BoardHeight)
+	}
+	start := consts.BoardHeight - len(rows)
+	for i, line := range rows {
+		row := start + i
+		if len(line) != consts.BoardWidth {
+			return fmt.Errorf("board row %d: expected %d cells, got %d", i+1, consts.BoardWidth, len(line))
+		}
+		for x, r := range line {
+			bit := tetromino.Bitmask(1 << x)
+			var color lipgloss.Color
+			switch {
+			case r == emptyCell:
+				continue
+			case r == garbageCell:
+			case r == otherCell:
+				color = otherColor
+			case isPieceName(r):
+				color = tetromino.NewTetromino(string(r)).Color
+			default:
+				return fmt.Errorf("board row %d: unknown cell %q", i+1, r)
+			}
+			g.Board[row] |= bit
+			g.BoardColors[row][x] = color
+		}
+	}
+	return nil
+}
+
+// ContinuePrompt shows a saved game and asks whether to continue it.
+type ContinuePrompt struct {
+	Game     Model // The restored game
+	Continue bool  // The player chose to continue
+	Answered bool  // False if the player quit without answering
+}
+
+// NewContinuePrompt asks whether to continue game.
+func NewContinuePrompt(game Model) ContinuePrompt {
+	return ContinuePrompt{Game: game}
+}
+
+func (c ContinuePrompt) Init() tea.Cmd {
+	return nil
+}
+
+func (c ContinuePrompt) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		c.Game.Width, c.Game.Height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "y", "enter":
+			c.Continue, c.Answered = true, true
+			return c, tea.Quit
+		case "n":
+			c.Answered = true
+			return c, tea.Quit
+		case "ctrl+c", "q", "esc":
+			return c, tea.Quit
+		}
+	}
+	return c, nil
+}
+
+func (c ContinuePrompt) View() string {
+	b := c.Game.screen()
+	x, y := drawGame(b, &c.Game.State, nil, c.Game.display(), c.Game.Width, c.Game.Height)
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	writeString(b, x+5, y+12, "Continue?", style)
+	writeString(b, x+3, y+14, "y: yes  n: no", style)
+	return b.Render()
+}
@@ -0,0 +1,107 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// playFrames drives g with a fixed input pattern and incoming garbage.
+func playFrames(g *GameState, from, to int) {
+	inputs := []Action{
+		ActionDASLeft, ActionHardDrop, ActionDASRight, ActionHardDrop,
+		ActionRotateCW, ActionLeft, ActionHardDrop, ActionHold, ActionRight, ActionRight, ActionHardDrop,
+		ActionRotateCCW, ActionLeft, ActionLeft, ActionLeft, ActionHardDrop,
+	}
+	for frame := from; frame < to && !g.GameOver; frame++ {
+		if frame%10 == 0 {
+			g.Apply(inputs[frame/10%len(inputs)])
+		}
+		if frame%300 == 0 {
+			g.ReceiveGarbage(1)
+		}
+		g.ApplyGravity(1.0 / 60.0)
+	}
+}
+
+func TestSave_ResumesIdentically(t *testing.T) {
+	m := NewModelWithMode(7, ModeMarathon)
+	m.State.Messiness = 0.5
+	playFrames(&m.State, 0, 300)
+	if m.State.GameOver {
+		t.Fatal("game ended before it was saved")
+	}
+
+	data, err := json.Marshal(m.Save())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var save SavedGame
+	if err := json.Unmarshal(data, &save); err != nil {
+		t.Fatal(err)
+	}
+	resumed, err := NewModelFromSave(save)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resumed.State.Paused {
+		t.Error("a resumed game does not start paused")
+	}
+	resumed.State.Paused = false
+
+	playFrames(&m.State, 300, 1500)
+	playFrames(&resumed.State, 300, 1500)
+
+	want, got := &m.State, &resumed.State
+	if got.FormatBoard() != want.FormatBoard() {
+		t.Errorf("board after resuming:\n%s\nwant:\n%s", got.FormatBoard(), want.FormatBoard())
+	}
+	if got.Score != want.Score || got.PiecesPlaced != want.PiecesPlaced || got.Stats != want.Stats || got.Elapsed != want.Elapsed {
+		t.Errorf("resumed game: score %d, pieces %d, stats %+v, elapsed %v; want %d, %d, %+v, %v",
+			got.Score, got.PiecesPlaced, got.Stats, got.Elapsed, want.Score, want.PiecesPlaced, want.Stats, want.Elapsed)
+	}
+}
+
+func TestRestoreRandomizer(t *testing.T) {
+	r := NewRandomizerWithSeed(3)
+	for range 10 {
+		r.Next()
+	}
+	restored, err := RestoreRandomizer(r.State())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 30 {
+		if want, got := r.Next().Name, restored.Next().Name; got != want {
+			t.Fatalf("piece %d = %s, want %s", i, got, want)
+		}
+	}
+}
+
+func TestNewModelFromSave_RejectsOtherVersions(t *testing.T) {
+	m := NewModelWithSeed(1)
+	save := m.Save()
+	save.Version++
+	if _, err := NewModelFromSave(save); !errors.Is(err, ErrSaveVersion) {
+		t.Errorf("error = %v, want ErrSaveVersion", err)
+	}
+}
+
+func TestModel_SavesOnQuit(t *testing.T) {
+	var saved []SavedGame
+	m := NewModelWithSeed(1)
+	m.SaveSlot = func(s SavedGame) { saved = append(saved, s) }
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if len(saved) != 1 {
+		t.Fatalf("quitting saved %d games, want 1", len(saved))
+	}
+
+	m.State.GameOver = true
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if len(saved) != 1 {
+		t.Error("quitting after the game ended saved it")
+	}
+}
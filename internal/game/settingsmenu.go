@@ -0,0 +1,147 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+
+	"termino/internal/render"
+	"termino/internal/settings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// settingsItem is a line of the settings menu.
+type settingsItem struct {
+	label  string
+	value  func(s settings.Settings) string
+	adjust func(s *settings.Settings, dir int)
+}
+
+var settingsItems = []settingsItem{
+	{
+		label: "Lock*",
+		value: func(s settings.Settings) string { return fmt.Sprintf("%dms", s.LockDelay) },
+		adjust: func(s *settings.Settings, dir int) {
+			s.LockDelay = clampStep(s.LockDelay, dir*50, settings.MinLockDelay, settings.MaxLockDelay)
+		},
+	},
+	{
+		label:  "Preview",
+		value:  func(s settings.Settings) string { return fmt.Sprint(s.Preview) },
+		adjust: func(s *settings.Settings, dir int) { s.Preview = clampStep(s.Preview, dir, 0, settings.MaxPreview) },
+	},
+	{
+		label: "Ghost",
+		value: func(s settings.Settings) string {
+			if s.Ghost {
+				return "on"
+			}
+			return "off"
+		},
+		adjust: func(s *settings.Settings, dir int) { s.Ghost = !s.Ghost },
+	},
+	{
+		label: "Palette",
+		value: func(s settings.Settings) string { return s.Palette },
+		adjust: func(s *settings.Settings, dir int) {
+			n := len(settings.Palettes)
+			i := max(slices.Index(settings.Palettes, s.Palette), 0)
+			s.Palette = settings.Palettes[(i+dir+n)%n]
+		},
+	},
+}
+
+func clampStep(v, step, lo, hi int) int {
+	return min(max(v+step, lo), hi)
+}
+
+// SettingsMenu edits settings over a game, which is drawn with the settings
+// being edited as a live preview.
+type SettingsMenu struct {
+	Settings settings.Settings
+	original settings.Settings
+	cursor   int
+}
+
+// NewSettingsMenu starts editing s.
+func NewSettingsMenu(s settings.Settings) *SettingsMenu {
+	return &SettingsMenu{Settings: s, original: s}
+}
+
+// Update handles a key. It returns done once the menu is closed, and save if
+// the settings were confirmed rather than discarded.
+func (m *SettingsMenu) Update(key tea.KeyMsg) (done, save bool) {
+	switch key.String() {
+	case "up", "k":
+		m.cursor = (m.cursor + len(settingsItems) - 1) % len(settingsItems)
+	case "down", "j":
+		m.cursor = (m.cursor + 1) % len(settingsItems)
+	case "left", "h":
+		settingsItems[m.cursor].adjust(&m.Settings, -1)
+	case "right", "l", " ":
+		settingsItems[m.cursor].adjust(&m.Settings, 1)
+	case "enter":
+		return true, true
+	case "esc", "o", "q", "ctrl+c":
+		m.Settings = m.original
+		return true, false
+	}
+	return false, false
+}
+
+// draw draws the menu over the top of the board at x, y.
+func (m *SettingsMenu) draw(b *render.Buffer, x, y int) {
+	const width = 20
+	rows := len(settingsItems) + 6
+	for row := range rows {
+		for col := range width {
+			b.Set(x+1+col, y+1+row, ' ', lipgloss.NewStyle())
+		}
+	}
+
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	selected := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFF00")).Bold(true)
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+
+	writeString(b, x+2, y+1, "SETTINGS", selected)
+	for i, item := range settingsItems {
+		line := fmt.Sprintf("  %-8s%8s", item.label, item.value(m.Settings))
+		lineStyle := style
+		if i == m.cursor {
+			line = ">" + line[1:]
+			lineStyle = selected
+		}
+		writeString(b, x+1, y+3+i, line, lineStyle)
+	}
+	writeString(b, x+2, y+rows-2, "*from next game", dim)
+	writeString(b, x+2, y+rows-1, "enter save esc back", dim)
+}
+
+// SettingsScreen is the settings menu outside of a game, previewed over a
+// fresh board. It quits once the menu is closed.
+type SettingsScreen struct {
+	Model
+}
+
+// NewSettingsScreen edits s, calling saved if the changes are confirmed.
+func NewSettingsScreen(s settings.Settings, saved func(s settings.Settings)) SettingsScreen {
+	m := NewModelWithSeed(1)
+	m.Settings, m.SettingsSaved = s, saved
+	m.menu = NewSettingsMenu(s)
+	m.menuPaused, m.State.Paused = true, true
+	return SettingsScreen{m}
+}
+
+func (s SettingsScreen) Init() tea.Cmd {
+	return nil
+}
+
+func (s SettingsScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, _ := s.Model.Update(msg)
+	s.Model = next.(Model)
+	if s.menu == nil {
+		return s, tea.Quit
+	}
+	return s, nil
+}
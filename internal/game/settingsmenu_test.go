@@ -0,0 +1,98 @@
+package game
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"termino/internal/settings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func key(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "up":
+		return tea.KeyMsg{Type: tea.KeyUp}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case "left":
+		return tea.KeyMsg{Type: tea.KeyLeft}
+	case "right":
+		return tea.KeyMsg{Type: tea.KeyRight}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func pressKeys(m Model, keys ...string) Model {
+	for _, k := range keys {
+		next, _ := m.Update(key(k))
+		m = next.(Model)
+	}
+	return m
+}
+
+func TestSettingsMenu_SaveAndCancel(t *testing.T) {
+	var saved []settings.Settings
+	m := NewModelWithSeed(1)
+	m.SettingsSaved = func(s settings.Settings) { saved = append(saved, s) }
+
+	// Preview is the second item.
+	m = pressKeys(m, "o", "down", "right")
+	if !m.State.Paused || m.display().Preview != settings.DefaultPreview+1 {
+		t.Errorf("while editing: paused %v, previewed %d pieces", m.State.Paused, m.display().Preview)
+	}
+	m = pressKeys(m, "esc")
+	if m.Settings.Preview != settings.DefaultPreview || m.display().Preview != settings.DefaultPreview || len(saved) != 0 {
+		t.Errorf("cancelling kept preview %d, display %d, saved %d times", m.Settings.Preview, m.display().Preview, len(saved))
+	}
+	if m.State.Paused {
+		t.Error("the game stayed paused after the menu closed")
+	}
+
+	m = pressKeys(m, "o", "down", "right", "enter")
+	if len(saved) != 1 || saved[0].Preview != settings.DefaultPreview+1 || m.Settings.Preview != saved[0].Preview {
+		t.Errorf("saving: settings %+v, saved %+v", m.Settings, saved)
+	}
+}
+
+func TestUseSettings_LockDelayFromNextGame(t *testing.T) {
+	s := settings.Default()
+	s.LockDelay = 1000
+
+	m := NewModelWithSeed(1)
+	m.UseSettings(s)
+	if m.State.LockDelay != s.LockDelayDuration() || m.Replay.LockDelay != s.LockDelayDuration() {
+		t.Errorf("new game lock delay = %v, replay %v, want 1s", m.State.LockDelay, m.Replay.LockDelay)
+	}
+
+	m.Frame = 10
+	s.LockDelay = 200
+	m.UseSettings(s)
+	if m.State.LockDelay != 1000*time.Millisecond {
+		t.Errorf("lock delay changed mid-game to %v", m.State.LockDelay)
+	}
+	m = pressKeys(m, "r")
+	if m.State.LockDelay != 200*time.Millisecond {
+		t.Errorf("lock delay after restarting = %v, want 200ms", m.State.LockDelay)
+	}
+}
+
+func TestView_DrawsWithOwnSettings(t *testing.T) {
+	hidden := settings.Default()
+	hidden.Preview = 0
+	a, b := NewModelWithSeed(1), NewModelWithSeed(1)
+	a.UseSettings(hidden)
+
+	if view := ansi.Strip(a.View()); strings.Contains(view, "Next:") {
+		t.Errorf("a model with no preview drew the next queue:\n%s", view)
+	}
+	if view := ansi.Strip(b.View()); !strings.Contains(view, "Next:") {
+		t.Errorf("another model's settings hid the next queue:\n%s", view)
+	}
+}
@@ -5,34 +5,46 @@ import (
 	"termino/pkg/consts"
 )
 
+// Board is the collision bitboard. Row 0 is the top; bit x of a row is column x.
+type Board = [consts.BoardHeight]tetromino.Bitmask
+
+// boardMask has a bit set for every column of a row.
+const boardMask = tetromino.Bitmask(1<<consts.BoardWidth - 1)
+
 // canPlace checks if the current piece can be placed at the specified position and rotation.
 // It validates against board boundaries and existing blocks.
 func (g *GameState) canPlace(pieceName string, x, y, rotation int) bool {
-	currentMasks := g.CurrentPiece.Masks[rotation]
-
-	for row := range 4 {
-		boardRow := y + row
+	return fits(&g.Board, &g.CurrentPiece, x, y, rotation)
+}
 
-		pieceRowMask := currentMasks[row]
-		if pieceRowMask == 0 {
+// fits reports whether piece in the given rotation can occupy x, y on board.
+// Each piece row is shifted into place and tested against the board row in one operation.
+func fits(board *Board, piece *tetromino.Tetromino, x, y, rotation int) bool {
+	for row, mask := range piece.Masks[rotation] {
+		if mask == 0 {
 			continue
 		}
 
+		boardRow := y + row
 		if boardRow < 0 || boardRow >= consts.BoardHeight {
 			return false
 		}
 
-		for col := range 4 {
-			if (pieceRowMask & (1 << col)) != 0 {
-				boardCol := x + col
-				if boardCol < 0 || boardCol >= consts.BoardWidth {
-					return false
-				}
-
-				if (g.Board[boardRow] & tetromino.Bitmask(1<<boardCol)) != 0 {
-					return false
-				}
+		var shifted tetromino.Bitmask
+		if x >= 0 {
+			shifted = mask << x
+			if shifted&^boardMask != 0 || shifted>>x != mask {
+				return false
 			}
+		} else {
+			if mask&((1<<-x)-1) != 0 {
+				return false
+			}
+			shifted = mask >> -x
+		}
+
+		if board[boardRow]&shifted != 0 {
+			return false
 		}
 	}
 	return true
@@ -52,26 +64,43 @@ func (g *GameState) RotateCCW() bool {
 
 // tryRotate attempts rotation with SRS wall kick tests. Returns true if rotation succeeds.
 func (g *GameState) tryRotate(newRotation int) bool {
-	kickData := g.CurrentPiece.KickData
-	from := g.CurrentRotation
-	to := newRotation
+	x, y, test, ok := kickTest(&g.Board, &g.CurrentPiece, g.CurrentX, g.CurrentY, g.CurrentRotation, newRotation)
+	if !ok {
+		return false
+	}
+	g.lastRotated = true
+	g.lastKick = test
+
+	g.CurrentX = x
+	g.CurrentY = y
+	g.CurrentRotation = newRotation
+	g.LockResets++
+	g.LockTimer = 0
+	return true
+}
+
+// kick runs the SRS wall kick tests for rotating piece from one rotation to another
+// and returns the first position that fits.
+func kick(board *Board, piece *tetromino.Tetromino, x, y, from, to int) (int, int, bool) {
+	x, y, _, ok := kickTest(board, piece, x, y, from, to)
+	return x, y, ok
+}
+
+// kickTest is kick that also returns the index of the test that succeeded.
+func kickTest(board *Board, piece *tetromino.Tetromino, x, y, from, to int) (int, int, int, bool) {
+	kickData := piece.KickData
 
 	for i := range 5 {
 		dx := kickData[from][to][i][0]
 		dy := kickData[from][to][i][1]
 
-		testX := g.CurrentX + dx
-		testY := g.CurrentY - dy
+		testX := x + dx
+		testY := y - dy
 
-		if g.canPlace(g.CurrentPiece.Name, testX, testY, newRotation) {
-			g.CurrentX = testX
-			g.CurrentY = testY
-			g.CurrentRotation = newRotation
-			g.LockResets++
-			g.LockTimer = 0
-			return true
+		if fits(board, piece, testX, testY, to) {
+			return testX, testY, i, true
 		}
 	}
 
-	return false
+	return x, y, 0, false
 }
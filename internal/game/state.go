@@ -1,8 +1,10 @@
 package game
 
 import (
+	"math/rand"
 	"time"
 
+	"termino/internal/settings"
 	"termino/internal/tetromino"
 	"termino/pkg/consts"
 
@@ -19,12 +21,26 @@ type GameState struct {
 	HoldUsed           bool
 	NextQueue          []tetromino.Tetromino // Circular buffer or just a slice from Randomizer logic
 	Randomizer         *Randomizer
+	Seed               int64 // Seed the randomizer was created with
 
 	Score        int
 	Level        int
 	LinesCleared int
 	BackToBack   bool
-	Combo        int
+	Combo        int // Consecutive line clears so far
+	PiecesPlaced int
+	LastClear    Clear
+	Stats        Stats // Counters for the statistics history
+
+	PendingGarbage []int   // Incoming garbage batches, oldest first
+	Outgoing       int     // Attack not yet collected by an opponent
+	AttackSent     int     // Total lines sent after cancellation
+	Messiness      float64 // Chance a garbage row's hole moves from the row below
+	garbageRand    *rand.Rand
+	garbageSrc     *countingSource
+
+	lastRotated bool // The last successful input was a rotation
+	lastKick    int  // Index of the kick test that rotation used
 
 	LockDelay    time.Duration
 	LockTimer    time.Duration
@@ -37,12 +53,33 @@ type GameState struct {
 
 	GhostY int
 
+	PieceInputs   int           // Inputs spent on the active piece, DAS runs counted once
+	FinessePieces int           // Pieces judged for finesse
+	FinesseFaults int           // Judged pieces that took more inputs than needed
+	FinesseFlash  time.Duration // Time left to show the last finesse fault
+
+	inputRun         Action // Last input, extended by repeated moves in one direction
+	inputRunStart    int    // PieceInputs before inputRun began
+	inputRunLen      int
+	pieceSoftDropped bool
+
+	Mode      Mode          // Goal of the game, marathon when empty
+	LineGoal  int           // Lines to clear in sprint, SprintLines when zero
+	Elapsed   time.Duration // Time played, advanced by gravity
+	Completed bool          // The mode's goal was reached, which also ends the game
+	Ending    Ending        // Why the game ended
+
 	GameOver bool
 	Paused   bool
 }
 
 func NewGameState() GameState {
-	r := NewRandomizer()
+	return NewGameStateWithSeed(time.Now().UnixNano())
+}
+
+// NewGameStateWithSeed creates a game whose piece sequence is fully determined by seed.
+func NewGameStateWithSeed(seed int64) GameState {
+	r := NewRandomizerWithSeed(seed)
 	queue := make([]tetromino.Tetromino, 0, consts.PreviewCount)
 
 	for range consts.PreviewCount {
@@ -51,10 +88,12 @@ func NewGameState() GameState {
 
 	g := GameState{
 		Randomizer: r,
+		Seed:       seed,
 		NextQueue:  queue,
 		Level:      1,
-		LockDelay:  time.Millisecond * 500,
+		LockDelay:  settings.DefaultLockDelay * time.Millisecond,
 	}
+	g.garbageRand, g.garbageSrc = newCountedRand(garbageSeed(seed), 0)
 	g.SpawnNewPiece()
 	g.UpdateGhost()
 	return g
@@ -63,19 +102,27 @@ func NewGameState() GameState {
 // SpawnNewPiece retrieves the next piece from the queue, updates the next queue,
 // and initializes it at the spawn position. Returns false if the spawn position collides (game over).
 func (g *GameState) SpawnNewPiece() bool {
-	g.CurrentPiece = g.NextQueue[0]
+	piece := g.NextQueue[0]
 	g.NextQueue = g.NextQueue[1:]
 	g.NextQueue = append(g.NextQueue, g.Randomizer.Next())
+	return g.SpawnPiece(piece)
+}
 
+// SpawnPiece makes piece the active piece at the spawn position without touching the queue.
+// Returns false if the spawn position collides (game over).
+func (g *GameState) SpawnPiece(piece tetromino.Tetromino) bool {
+	g.CurrentPiece = piece
 	g.CurrentX = consts.BoardWidth/2 - 2
 	g.CurrentY = 20
 	g.CurrentRotation = 0
 	g.HoldUsed = false
 	g.LockResets = 0
 	g.LockTimer = 0
+	g.lastRotated = false
+	g.resetInputs()
 
 	if !g.canPlace(g.CurrentPiece.Name, g.CurrentX, g.CurrentY, g.CurrentRotation) {
-		g.GameOver = true
+		g.end(EndBlockOut)
 		return false
 	}
 	return true
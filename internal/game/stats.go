@@ -0,0 +1,107 @@
+package game
+
+// ClearKind classifies a line clear or T-spin for the statistics.
+type ClearKind int
+
+const (
+	ClearSingle ClearKind = iota
+	ClearDouble
+	ClearTriple
+	ClearTetris
+	ClearTSpinMini // T-spin mini without lines
+	ClearTSpinMiniSingle
+	ClearTSpinMiniDouble
+	ClearTSpin // T-spin without lines
+	ClearTSpinSingle
+	ClearTSpinDouble
+	ClearTSpinTriple
+	ClearPerfect // Perfect clear, counted on top of the clear's own kind
+	NumClearKinds
+)
+
+var clearKindNames = [NumClearKinds]string{
+	"single", "double", "triple", "tetris",
+	"tspin_mini", "tspin_mini_single", "tspin_mini_double",
+	"tspin", "tspin_single", "tspin_double", "tspin_triple",
+	"perfect_clear",
+}
+
+// String returns the kind's name as stored in the statistics history.
+func (k ClearKind) String() string {
+	if k < 0 || k >= NumClearKinds {
+		return "unknown"
+	}
+	return clearKindNames[k]
+}
+
+// Kind returns the kind of the clear, or false for a lock that neither
+// cleared lines nor scored a T-spin.
+func (c Clear) Kind() (ClearKind, bool) {
+	switch c.TSpin {
+	case TSpinMini:
+		return ClearTSpinMini + ClearKind(min(c.Lines, 2)), true
+	case TSpinFull:
+		return ClearTSpin + ClearKind(min(c.Lines, 3)), true
+	}
+	if c.Lines == 0 {
+		return 0, false
+	}
+	return ClearSingle + ClearKind(min(c.Lines, 4)-1), true
+}
+
+// Stats counts what happened during a game beyond the score, for the
+// statistics history. Pieces, attack, finesse and time are kept on GameState.
+type Stats struct {
+	Inputs   int                // Gameplay actions applied
+	Holds    int                // Successful holds
+	Clears   [NumClearKinds]int // Locks by kind of clear
+	MaxCombo int                // Most consecutive clears after the first
+	B2BChain int                // Back-to-back clears in the current chain
+	MaxB2B   int                // Longest back-to-back chain
+	Last     Clear              // Last lock that cleared lines or scored a T-spin
+}
+
+// record counts a locked piece's clear.
+func (s *Stats) record(c Clear) {
+	if kind, ok := c.Kind(); ok {
+		s.Clears[kind]++
+		s.Last = c
+	}
+	if c.PerfectClear {
+		s.Clears[ClearPerfect]++
+	}
+	if c.Lines > 0 {
+		s.MaxCombo = max(s.MaxCombo, c.Combo)
+	}
+	switch {
+	case c.B2B:
+		s.B2BChain++
+		s.MaxB2B = max(s.MaxB2B, s.B2BChain)
+	case c.Lines > 0:
+		s.B2BChain = 0
+	}
+}
+
+// PPS returns the pieces placed per second so far.
+func (g *GameState) PPS() float64 {
+	if g.Elapsed <= 0 {
+		return 0
+	}
+	return float64(g.PiecesPlaced) / g.Elapsed.Seconds()
+}
+
+// APM returns the garbage lines sent per minute so far.
+func (g *GameState) APM() float64 {
+	if g.Elapsed <= 0 {
+		return 0
+	}
+	return float64(g.AttackSent) / g.Elapsed.Minutes()
+}
+
+// KPP returns the inputs spent per piece placed so far.
+func (g *GameState) KPP() float64 {
+	if g.PiecesPlaced == 0 {
+		return 0
+	}
+	return float64(g.Stats.Inputs) / float64(g.PiecesPlaced)
+}
@@ -0,0 +1,93 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestClear_Kind(t *testing.T) {
+	tests := []struct {
+		clear Clear
+		want  ClearKind
+		ok    bool
+	}{
+		{Clear{}, 0, false},
+		{Clear{Lines: 1}, ClearSingle, true},
+		{Clear{Lines: 4, B2B: true}, ClearTetris, true},
+		{Clear{TSpin: TSpinMini}, ClearTSpinMini, true},
+		{Clear{Lines: 2, TSpin: TSpinMini}, ClearTSpinMiniDouble, true},
+		{Clear{TSpin: TSpinFull}, ClearTSpin, true},
+		{Clear{Lines: 3, TSpin: TSpinFull}, ClearTSpinTriple, true},
+	}
+	for _, tt := range tests {
+		got, ok := tt.clear.Kind()
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%+v: Kind = %v, %v, want %v, %v", tt.clear, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestStats_Record(t *testing.T) {
+	var s Stats
+	for _, c := range []Clear{
+		{Lines: 4},
+		{Lines: 4, B2B: true, Combo: 1},
+		{Lines: 2, TSpin: TSpinFull, B2B: true, Combo: 2},
+		{},
+		{Lines: 1},
+		{Lines: 4, PerfectClear: true},
+	} {
+		s.record(c)
+	}
+
+	if s.Clears[ClearTetris] != 3 || s.Clears[ClearTSpinDouble] != 1 || s.Clears[ClearSingle] != 1 || s.Clears[ClearPerfect] != 1 {
+		t.Errorf("Clears = %v", s.Clears)
+	}
+	if s.MaxCombo != 2 {
+		t.Errorf("MaxCombo = %d, want 2", s.MaxCombo)
+	}
+	if s.MaxB2B != 2 || s.B2BChain != 0 {
+		t.Errorf("MaxB2B = %d, B2BChain = %d, want 2 and 0", s.MaxB2B, s.B2BChain)
+	}
+}
+
+func TestStats_InputsAndHolds(t *testing.T) {
+	g := NewGameStateWithSeed(1)
+	g.Apply(ActionHold)
+	g.Apply(ActionHold) // Ignored until the next piece, but still an input
+	g.Apply(ActionLeft)
+	g.Apply(ActionHardDrop)
+	g.Apply(ActionHold)
+
+	if g.Stats.Inputs != 5 || g.Stats.Holds != 2 {
+		t.Errorf("Inputs = %d, Holds = %d, want 5 and 2", g.Stats.Inputs, g.Stats.Holds)
+	}
+}
+
+func TestStats_LastKeepsNoteworthyClear(t *testing.T) {
+	var s Stats
+	s.record(Clear{Lines: 2, TSpin: TSpinFull})
+	s.record(Clear{})
+	if got := s.Last.Name(); got != "T-SPIN DOUBLE" {
+		t.Errorf("Last = %q after a lock without lines, want T-SPIN DOUBLE", got)
+	}
+}
+
+func TestModelView_StatsPanel(t *testing.T) {
+	m := NewModelWithSeed(1)
+	m.ShowStats = true
+	m.State.Stats.Last = Clear{Lines: 4, B2B: true}
+
+	for _, tt := range []struct {
+		width int
+		want  bool
+	}{{80, true}, {64, true}, {56, false}} {
+		m.Width, m.Height = tt.width, 24
+		view := ansi.Strip(m.View())
+		if got := strings.Contains(view, "PPS") && strings.Contains(view, "TETRIS"); got != tt.want {
+			t.Errorf("width %d: panel shown = %v, want %v", tt.width, got, tt.want)
+		}
+	}
+}
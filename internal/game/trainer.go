@@ -0,0 +1,242 @@
+package game
+
+import (
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"strings"
+	"time"
+
+	"termino/internal/settings"
+	"termino/internal/tetromino"
+	"termino/pkg/consts"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TrainerRecord counts attempts at one kind of finesse target.
+type TrainerRecord struct {
+	Attempts int
+	Correct  int
+	Time     time.Duration // Total time spent on correct attempts
+}
+
+// Accuracy returns the percentage of attempts placed with optimal finesse.
+func (r TrainerRecord) Accuracy() float64 {
+	if r.Attempts == 0 {
+		return 0
+	}
+	return 100 * float64(r.Correct) / float64(r.Attempts)
+}
+
+// AverageTime returns the mean time of correct attempts.
+func (r TrainerRecord) AverageTime() time.Duration {
+	if r.Correct == 0 {
+		return 0
+	}
+	return r.Time / time.Duration(r.Correct)
+}
+
+// TrainerStats are the finesse trainer's results, kept across sessions.
+type TrainerStats struct {
+	Pieces  map[string]TrainerRecord
+	Columns [consts.BoardWidth]TrainerRecord // By the leftmost column of the target
+}
+
+func (s *TrainerStats) record(piece string, column int, correct bool, d time.Duration) {
+	if s.Pieces == nil {
+		s.Pieces = make(map[string]TrainerRecord)
+	}
+	p, c := s.Pieces[piece], s.Columns[column]
+	p.Attempts++
+	c.Attempts++
+	if correct {
+		p.Correct++
+		c.Correct++
+		p.Time += d
+		c.Time += d
+	}
+	s.Pieces[piece], s.Columns[column] = p, c
+}
+
+// Trainer is a practice mode that shows a target placement on an empty board
+// and only accepts it when reached with optimal finesse. Any extra input, a
+// soft drop or a wrong placement is a fault that restarts the piece.
+type Trainer struct {
+	State  GameState
+	Stats  TrainerStats
+	Width  int
+	Height int
+
+	rng              *rand.Rand
+	target           Placement
+	column           int
+	optimal          Finesse
+	started          time.Time
+	streak           int
+	result           string
+	lastSpacePressed bool
+}
+
+// NewTrainer creates a trainer whose targets are determined by seed.
+func NewTrainer(seed int64, stats TrainerStats) Trainer {
+	t := Trainer{
+		State:  NewGameStateWithSeed(seed),
+		Stats:  stats,
+		Width:  80,
+		Height: 24,
+		rng:    rand.New(rand.NewSource(seed)),
+	}
+	t.State.NextQueue = nil
+	t.nextTarget()
+	return t
+}
+
+// nextTarget picks a random piece and one of its placements.
+func (t *Trainer) nextTarget() {
+	names := []string{"I", "J", "L", "O", "S", "T", "Z"}
+	t.State.SpawnPiece(tetromino.NewTetromino(names[t.rng.Intn(len(names))]))
+	t.State.UpdateGhost()
+
+	placements := t.State.Placements()
+	t.target = placements[t.rng.Intn(len(placements))]
+	t.optimal, _ = FinesseFor(&t.State.CurrentPiece, t.target.X, t.target.Rotation)
+
+	_, rows := cellRows(&t.State.CurrentPiece, t.target.X, t.target.Y, t.target.Rotation)
+	var cols tetromino.Bitmask
+	for _, row := range rows {
+		cols |= row
+	}
+	t.column = bits.TrailingZeros16(uint16(cols))
+	t.started = time.Now()
+}
+
+// restart puts the same piece back at the spawn position.
+func (t *Trainer) restart() {
+	t.State.SpawnPiece(t.State.CurrentPiece)
+	t.State.UpdateGhost()
+	t.started = time.Now()
+}
+
+func (t *Trainer) fault(reason string) {
+	t.Stats.record(t.State.CurrentPiece.Name, t.column, false, 0)
+	t.streak = 0
+	t.result = fmt.Sprintf("%s, optimal: %s", reason, t.optimal)
+	t.State.FinesseFlash = finesseFlashTime
+	t.restart()
+}
+
+func (t Trainer) Init() tea.Cmd {
+	return tea.Tick(time.Second/60, func(ts time.Time) tea.Msg {
+		return tickMsg(ts)
+	})
+}
+
+func (t Trainer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		t.Width = msg.Width
+		t.Height = msg.Height
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return t, tea.Quit
+		case "r":
+			t.restart()
+			return t, nil
+		}
+
+		action, ok := DefaultKeymap[msg.String()]
+		if !ok {
+			return t, nil
+		}
+		switch action {
+		case ActionHold:
+			return t, nil
+		case ActionSoftDrop:
+			t.fault("Soft drop")
+			return t, nil
+		case ActionHardDrop:
+			if t.lastSpacePressed {
+				return t, nil
+			}
+			t.lastSpacePressed = true
+			t.drop()
+			return t, nil
+		}
+
+		t.State.Apply(action)
+		if t.State.minPieceInputs() > len(t.optimal.Inputs) {
+			t.fault("Too many inputs")
+		}
+
+	case tickMsg:
+		t.lastSpacePressed = false
+		if t.State.FinesseFlash > 0 {
+			t.State.FinesseFlash -= time.Second / consts.TickRate
+		}
+		return t, tea.Tick(time.Second/60, func(ts time.Time) tea.Msg {
+			return tickMsg(ts)
+		})
+	}
+	return t, nil
+}
+
+// drop judges a hard drop against the target.
+func (t *Trainer) drop() {
+	piece := &t.State.CurrentPiece
+	gotTop, got := cellRows(piece, t.State.CurrentX, t.State.GhostY, t.State.CurrentRotation)
+	wantTop, want := cellRows(piece, t.target.X, t.target.Y, t.target.Rotation)
+	switch {
+	case gotTop != wantTop || got != want:
+		t.fault("Wrong placement")
+		return
+	case t.State.PieceInputs > len(t.optimal.Inputs):
+		t.fault("Too many inputs")
+		return
+	}
+
+	elapsed := time.Since(t.started)
+	t.Stats.record(piece.Name, t.column, true, elapsed)
+	t.streak++
+	t.result = fmt.Sprintf("OK in %dms", elapsed.Milliseconds())
+	t.nextTarget()
+}
+
+func (t Trainer) View() string {
+	x, y := drawGame(screen(), &t.State, &Suggestion{Placement: t.target}, settings.Default(), t.Width, t.Height)
+
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	piece := t.Stats.Pieces[t.State.CurrentPiece.Name]
+	column := t.Stats.Columns[t.column]
+	lines := []string{
+		"Finesse trainer",
+		"",
+		fmt.Sprintf("Inputs: %d/%d", t.State.PieceInputs, len(t.optimal.Inputs)),
+		fmt.Sprintf("Streak: %d", t.streak),
+		"",
+		fmt.Sprintf("%s piece: %3.0f%%", t.State.CurrentPiece.Name, piece.Accuracy()),
+		fmt.Sprintf("Column %d: %3.0f%%", t.column+1, column.Accuracy()),
+		fmt.Sprintf("Average: %dms", piece.AverageTime().Milliseconds()),
+		"",
+		t.result,
+	}
+	for i, line := range lines {
+		writeString(ScreenBuffer, x+24, y+i, line, style)
+	}
+	return ScreenBuffer.Render()
+}
+
+// String lists the inputs, or "none" if the piece only needs a hard drop.
+func (f Finesse) String() string {
+	if len(f.Inputs) == 0 {
+		return "none"
+	}
+	parts := make([]string, len(f.Inputs))
+	for i, a := range f.Inputs {
+		parts[i] = string(a)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,81 @@
+package game
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// press sends the key bound to a through the trainer. DAS moves are sent as
+// the repeated presses a held key produces.
+func press(t Trainer, a Action) Trainer {
+	keys := map[Action]tea.KeyMsg{
+		ActionLeft:      {Type: tea.KeyLeft},
+		ActionRight:     {Type: tea.KeyRight},
+		ActionSoftDrop:  {Type: tea.KeyDown},
+		ActionHardDrop:  {Type: tea.KeySpace, Runes: []rune{' '}},
+		ActionRotateCW:  {Type: tea.KeyRunes, Runes: []rune{'x'}},
+		ActionRotateCCW: {Type: tea.KeyRunes, Runes: []rune{'c'}},
+	}
+	repeat := 1
+	switch a {
+	case ActionDASLeft:
+		a, repeat = ActionLeft, 9
+	case ActionDASRight:
+		a, repeat = ActionRight, 9
+	}
+	for range repeat {
+		m, _ := t.Update(keys[a])
+		t = m.(Trainer)
+	}
+	if a == ActionHardDrop {
+		m, _ := t.Update(tickMsg{})
+		t = m.(Trainer)
+	}
+	return t
+}
+
+func TestTrainer_AcceptsOptimalInputs(t *testing.T) {
+	tr := NewTrainer(1, TrainerStats{})
+	for i := range 50 {
+		for _, a := range tr.optimal.Inputs {
+			tr = press(tr, a)
+		}
+		tr = press(tr, ActionHardDrop)
+		if tr.streak != i+1 {
+			t.Fatalf("target %d: streak %d, result %q", i, tr.streak, tr.result)
+		}
+	}
+
+	total := 0
+	for _, r := range tr.Stats.Pieces {
+		if r.Attempts != r.Correct {
+			t.Errorf("record %+v has misses", r)
+		}
+		total += r.Correct
+	}
+	if total != 50 {
+		t.Errorf("recorded %d correct pieces, want 50", total)
+	}
+}
+
+func TestTrainer_FaultRestartsPiece(t *testing.T) {
+	tr := NewTrainer(2, TrainerStats{})
+	piece, target := tr.State.CurrentPiece.Name, tr.target
+
+	tr = press(tr, ActionRotateCW)
+	tr = press(tr, ActionRotateCCW)
+	tr = press(tr, ActionRotateCW)
+	tr = press(tr, ActionRotateCCW)
+	tr = press(tr, ActionRotateCW)
+
+	if tr.streak != 0 || tr.State.FinesseFlash == 0 {
+		t.Fatalf("no fault after five rotations, result %q", tr.result)
+	}
+	if tr.State.CurrentPiece.Name != piece || tr.target.X != target.X || tr.target.Y != target.Y || tr.target.Rotation != target.Rotation {
+		t.Error("fault moved on to a new target")
+	}
+	if r := tr.Stats.Pieces[piece]; r.Attempts == 0 || r.Correct != 0 {
+		t.Errorf("record %+v, want a miss", r)
+	}
+}
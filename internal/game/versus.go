@@ -0,0 +1,199 @@
+package game
+
+import (
+	"time"
+
+	"termino/pkg/consts"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Keymaps for two players sharing one keyboard.
+var (
+	Player1Keymap = map[string]Action{
+		"a":   ActionLeft,
+		"d":   ActionRight,
+		"s":   ActionSoftDrop,
+		"w":   ActionHardDrop,
+		"e":   ActionRotateCW,
+		"q":   ActionRotateCCW,
+		"tab": ActionHold,
+	}
+	Player2Keymap = map[string]Action{
+		"left":  ActionLeft,
+		"right": ActionRight,
+		"down":  ActionSoftDrop,
+		"enter": ActionHardDrop,
+		"up":    ActionRotateCW,
+		"/":     ActionRotateCCW,
+		".":     ActionHold,
+	}
+)
+
+// Versus is a two-player game on one screen. Both players get the same piece
+// sequence, and the attack of each is sent to the other as garbage.
+type Versus struct {
+	Players [2]GameState
+	Keymaps [2]map[string]Action
+	Pilots  [2]*Pilot // Bots playing instead of the keyboard, nil for human players
+	Names   [2]string
+	Width   int
+	Height  int
+	Frame   int
+	Winner  int // Index of the winning player, -1 while playing or on a draw
+	Over    bool
+
+	lastHardDrop [2]bool
+	pilots       [2]autopilot
+}
+
+// NewBotVersus starts a match of the keyboard, with the single-player keys,
+// against a bot.
+func NewBotVersus(seed int64, pilot Pilot, name string) Versus {
+	v := NewVersus(seed)
+	v.Keymaps = [2]map[string]Action{DefaultKeymap, nil}
+	v.Pilots[1] = &pilot
+	v.Names = [2]string{"YOU", name}
+	return v
+}
+
+// NewVersus starts a match in which both players' pieces come from seed.
+func NewVersus(seed int64) Versus {
+	return Versus{
+		Players: [2]GameState{NewGameStateWithSeed(seed), NewGameStateWithSeed(seed)},
+		Keymaps: [2]map[string]Action{Player1Keymap, Player2Keymap},
+		Names:   [2]string{"PLAYER 1", "PLAYER 2"},
+		Width:   80,
+		Height:  24,
+		Winner:  -1,
+	}
+}
+
+func (v Versus) Init() tea.Cmd {
+	return tea.Tick(time.Second/60, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
+
+func (v Versus) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.Width = msg.Width
+		v.Height = msg.Height
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			return v, tea.Quit
+		case "p":
+			if !v.Over {
+				for i := range v.Players {
+					v.Players[i].Paused = !v.Players[i].Paused
+				}
+			}
+			return v, nil
+		case "r":
+			if v.Over {
+				next := NewVersus(time.Now().UnixNano())
+				next.Keymaps, next.Pilots, next.Names = v.Keymaps, v.Pilots, v.Names
+				next.Width, next.Height = v.Width, v.Height
+				return next, nil
+			}
+		}
+
+		if v.Over || v.Players[0].Paused {
+			return v, nil
+		}
+		for i, keymap := range v.Keymaps {
+			action, ok := keymap[msg.String()]
+			if !ok || v.Pilots[i] != nil {
+				continue
+			}
+			if action == ActionHardDrop {
+				if v.lastHardDrop[i] {
+					continue
+				}
+				v.lastHardDrop[i] = true
+			}
+			v.Players[i].Apply(action)
+		}
+		v.exchange()
+
+	case tickMsg:
+		v.lastHardDrop = [2]bool{}
+		var asks [2]tea.Cmd
+		if !v.Over && !v.Players[0].Paused {
+			for i := range v.Players {
+				if v.Pilots[i] != nil && !v.Players[i].GameOver {
+					asks[i] = v.pilots[i].stepAsync(&v.Players[i], *v.Pilots[i], v.Players[i].Apply)
+				}
+				v.Players[i].ApplyGravity(1.0 / consts.TickRate)
+			}
+			v.Frame++
+			v.exchange()
+		}
+		return v, tea.Batch(asks[0], asks[1], tea.Tick(time.Second/60, func(t time.Time) tea.Msg {
+			return tickMsg(t)
+		}))
+
+	case suggestionMsg:
+		// Only the bot that asked about msg's snapshot accepts it.
+		for i := range v.Players {
+			if v.Pilots[i] != nil {
+				v.pilots[i].deliver(&v.Players[i], *v.Pilots[i], msg)
+			}
+		}
+	}
+	return v, nil
+}
+
+// exchange delivers each player's attack to the other and ends the match
+// once someone has topped out.
+func (v *Versus) exchange() {
+	for i := range v.Players {
+		if sent := v.Players[i].Outgoing; sent > 0 {
+			v.Players[i].Outgoing = 0
+			v.Players[1-i].ReceiveGarbage(sent)
+		}
+	}
+
+	over := [2]bool{v.Players[0].GameOver, v.Players[1].GameOver}
+	switch {
+	case over[0] && over[1]:
+		v.Over = true
+	case over[0]:
+		v.Over, v.Winner = true, 1
+	case over[1]:
+		v.Over, v.Winner = true, 0
+	}
+}
+
+func (v Versus) View() string {
+	xs, y := DrawGames([]*GameState{&v.Players[0], &v.Players[1]}, v.Width, v.Height)
+
+	label := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Bold(true)
+	for i, x := range xs {
+		if y > 0 {
+			name := v.Names[i]
+			writeString(ScreenBuffer, x+1+(consts.BoardWidth*2-len(name))/2, y-1, name, label)
+		}
+	}
+
+	if v.Over {
+		win := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+		for i, x := range xs {
+			if v.Winner == i {
+				ScreenBuffer.DimArea(x+1, y+1, consts.BoardWidth*2, consts.VisibleHeight)
+				writeString(ScreenBuffer, x+7, y+8, "WINNER!", win)
+				writeString(ScreenBuffer, x+6, y+10, "Press 'r'", style)
+				writeString(ScreenBuffer, x+4, y+11, "for a rematch", style)
+			}
+		}
+		if v.Winner < 0 && len(xs) > 1 {
+			writeString(ScreenBuffer, xs[1]-GameLeft-gameGap/2-2, y+6, "DRAW", win)
+		}
+	}
+	return ScreenBuffer.Render()
+}
@@ -0,0 +1,156 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func updateVersus(v Versus, msg tea.Msg) Versus {
+	m, _ := v.Update(msg)
+	return m.(Versus)
+}
+
+// tickVersus advances v by a frame and answers the bot at once if it asked
+// for a suggestion.
+func tickVersus(t *testing.T, v Versus) Versus {
+	t.Helper()
+	next, cmd := v.Update(tickMsg{})
+	v = next.(Versus)
+	if v.pilots[1].asked != nil {
+		v = updateVersus(v, suggestion(t, cmd))
+	}
+	return v
+}
+
+func TestVersus_KeymapsRouteToPlayers(t *testing.T) {
+	v := NewVersus(1)
+	x0, x1 := v.Players[0].CurrentX, v.Players[1].CurrentX
+
+	v = updateVersus(v, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	v = updateVersus(v, tea.KeyMsg{Type: tea.KeyRight})
+
+	if v.Players[0].CurrentX != x0-1 || v.Players[1].CurrentX != x1+1 {
+		t.Errorf("positions %d and %d, want %d and %d", v.Players[0].CurrentX, v.Players[1].CurrentX, x0-1, x1+1)
+	}
+}
+
+func TestVersus_GarbageExchange(t *testing.T) {
+	v := NewVersus(1)
+	if err := v.Players[0].LoadBoard(`
+current: I
+GGGGGGGGG.
+GGGGGGGGG.
+GGGGGGGGG.
+GGGGGGGGG.
+G.GGGGGGGG`); err != nil {
+		t.Fatalf("LoadBoard failed: %v", err)
+	}
+	v.Players[1].ReceiveGarbage(1)
+
+	v.Players[0].Apply(ActionRotateCW)
+	v.Players[0].Apply(ActionDASRight)
+	v.Players[0].Apply(ActionHardDrop)
+	v = updateVersus(v, tickMsg{})
+
+	if v.Players[0].LastClear.Lines != 4 {
+		t.Fatalf("player 1 cleared %+v, want a tetris", v.Players[0].LastClear)
+	}
+	if got := v.Players[1].PendingLines(); got != 5 {
+		t.Errorf("player 2 has %d pending lines, want 5", got)
+	}
+}
+
+func TestVersus_TopOutEndsMatch(t *testing.T) {
+	v := NewVersus(1)
+	v.Players[1].GameOver = true
+	v = updateVersus(v, tickMsg{})
+
+	if !v.Over || v.Winner != 0 {
+		t.Errorf("Over = %v, Winner = %d, want player 1 to win", v.Over, v.Winner)
+	}
+
+	frame := v.Frame
+	v = updateVersus(v, tickMsg{})
+	if v.Frame != frame {
+		t.Error("match kept running after it ended")
+	}
+}
+
+// dropAdvisor suggests dropping the piece where it is.
+type dropAdvisor struct{}
+
+func (dropAdvisor) Suggest(g *GameState) (Suggestion, bool) {
+	return Suggestion{Placement: Placement{X: g.CurrentX, Y: g.GhostY, Rotation: g.CurrentRotation}}, !g.GameOver
+}
+
+func TestBotVersus_PaceCapsPieces(t *testing.T) {
+	v := NewBotVersus(1, Pilot{Bot: dropAdvisor{}, Interval: 1, Pace: 30}, "BOT")
+	for range 300 {
+		v = tickVersus(t, v)
+	}
+
+	if got := v.Players[1].PiecesPlaced; got < 8 || got > 10 {
+		t.Errorf("bot placed %d pieces in 300 frames at a pace of 30, want 8 to 10", got)
+	}
+	if got := v.Players[0].PiecesPlaced; got != 0 {
+		t.Errorf("player placed %d pieces, want 0", got)
+	}
+}
+
+func TestBotVersus_KeepsTickingWhileThinking(t *testing.T) {
+	calls := 0
+	v := NewBotVersus(1, Pilot{Bot: countAdvisor{&calls}, Interval: 1}, "BOT")
+
+	next, cmd := v.Update(tickMsg{})
+	v = next.(Versus)
+	for range 30 {
+		v = updateVersus(v, tickMsg{})
+	}
+	if calls != 0 || v.Frame != 31 || v.Players[1].PiecesPlaced != 0 {
+		t.Fatalf("While the bot thinks: %d calls, frame %d, %d pieces placed", calls, v.Frame, v.Players[1].PiecesPlaced)
+	}
+
+	v = updateVersus(v, suggestion(t, cmd))
+	for range 20 {
+		v = updateVersus(v, tickMsg{})
+	}
+	if calls != 1 || v.Players[1].PiecesPlaced != 1 || v.Players[0].PiecesPlaced != 0 {
+		t.Errorf("Expected the bot to play one suggestion, got %d calls and %d/%d pieces placed", calls, v.Players[0].PiecesPlaced, v.Players[1].PiecesPlaced)
+	}
+}
+
+func TestBotVersus_KeyboardOnlyMovesPlayer(t *testing.T) {
+	v := NewBotVersus(1, Pilot{Bot: dropAdvisor{}}, "BOT")
+	x0, x1 := v.Players[0].CurrentX, v.Players[1].CurrentX
+
+	v = updateVersus(v, tea.KeyMsg{Type: tea.KeyLeft})
+
+	if v.Players[0].CurrentX != x0-1 || v.Players[1].CurrentX != x1 {
+		t.Errorf("positions %d and %d, want %d and %d", v.Players[0].CurrentX, v.Players[1].CurrentX, x0-1, x1)
+	}
+}
+
+func TestVersus_FitsEightyColumns(t *testing.T) {
+	for _, tt := range []struct {
+		width, queues int
+	}{
+		{80, 1},
+		{84, 1},
+		{2*GameWidth + gameGap, 2},
+	} {
+		v := NewVersus(1)
+		v = updateVersus(v, tea.WindowSizeMsg{Width: tt.width, Height: 24})
+		view := ansi.Strip(v.View())
+
+		// A next queue cut off by the right edge leaves part of its label.
+		if n, whole := strings.Count(view, "Next"), strings.Count(view, "Next:"); n != tt.queues || whole != tt.queues {
+			t.Errorf("%d columns: expected %d whole next queues, got %d labels of which %d whole:\n%s", tt.width, tt.queues, n, whole, view)
+		}
+		if n := strings.Count(view, "Hold:"); n != 2 {
+			t.Errorf("%d columns: expected both hold boxes, got %d:\n%s", tt.width, n, view)
+		}
+	}
+}
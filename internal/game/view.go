@@ -2,7 +2,9 @@ package game
 
 import (
 	"fmt"
+	"strings"
 	"termino/internal/render"
+	"termino/internal/settings"
 	"termino/internal/tetromino"
 	"termino/pkg/consts"
 
@@ -16,7 +18,50 @@ func InitScreen() {
 	ScreenBuffer = render.NewBuffer(80, 24)
 }
 
-func RenderGame(state *GameState, screenW, screenH int) string {
+// screen returns ScreenBuffer, allocating it on first use.
+func screen() *render.Buffer {
+	if ScreenBuffer == nil {
+		InitScreen()
+	}
+	return ScreenBuffer
+}
+
+// RenderGame draws the game centred on a screen of the given size. If hint is
+// not nil, its placement is outlined on the board.
+func RenderGame(state *GameState, hint *Suggestion, screenW, screenH int) string {
+	drawGame(screen(), state, hint, settings.Default(), screenW, screenH)
+	return ScreenBuffer.Render()
+}
+
+// DrawGame draws the game into ScreenBuffer as RenderGame does, without
+// rendering it, and returns the position of the board's top-left corner.
+func DrawGame(state *GameState, screenW, screenH int) (x, y int) {
+	return drawGame(screen(), state, nil, settings.Default(), screenW, screenH)
+}
+
+// RenderGames draws several games side by side, centred on the screen.
+func RenderGames(states []*GameState, screenW, screenH int) string {
+	DrawGames(states, screenW, screenH)
+	return ScreenBuffer.Render()
+}
+
+// Horizontal extent of a game around its board: the hold and score column on
+// the left, and the board and next queue to its right.
+const (
+	GameLeft  = 10
+	GameWidth = GameLeft + 24 + 8
+	gameGap   = 4
+	gameNext  = 10 // Columns of the next queue and the space before it
+)
+
+// PrepareScreen resizes and clears ScreenBuffer for a screen of the given
+// size, substituting the default size for zero dimensions.
+func PrepareScreen(screenW, screenH int) (int, int) {
+	return PrepareBuffer(screen(), screenW, screenH)
+}
+
+// PrepareBuffer resizes and clears b as PrepareScreen does ScreenBuffer.
+func PrepareBuffer(b *render.Buffer, screenW, screenH int) (int, int) {
 	if screenW == 0 {
 		screenW = 80
 	}
@@ -24,28 +69,84 @@ func RenderGame(state *GameState, screenW, screenH int) string {
 		screenH = 24
 	}
 
-	if ScreenBuffer == nil || ScreenBuffer.Width() != screenW || ScreenBuffer.Height() != screenH {
-		ScreenBuffer = render.NewBuffer(screenW, screenH)
+	if b.Width() != screenW || b.Height() != screenH {
+		b.Resize(screenW, screenH)
 	}
 
-	ScreenBuffer.Reset()
+	b.Reset()
+	return screenW, screenH
+}
 
+// boardTop returns the row that vertically centres a board on the screen.
+func boardTop(screenH int) int {
+	return max((screenH-(consts.VisibleHeight+2))/2, 0)
+}
+
+// BoardOrigin returns the top-left corner of the board that RenderGame draws
+// on a screen of the given size.
+func BoardOrigin(screenW, screenH int) (x, y int) {
 	boardPixelW := consts.BoardWidth*2 + 2
-	boardPixelH := consts.VisibleHeight + 2
+	return max((screenW-boardPixelW)/2, 0), boardTop(screenH)
+}
 
-	offsetX := (screenW - boardPixelW) / 2
-	offsetY := (screenH - boardPixelH) / 2
+// drawGame draws the game into b with the preview length, ghost and palette of
+// display, resizing b to the screen, and returns the position of the board's
+// top-left corner.
+func drawGame(b *render.Buffer, state *GameState, hint *Suggestion, display settings.Settings, screenW, screenH int) (offsetX, offsetY int) {
+	screenW, screenH = PrepareBuffer(b, screenW, screenH)
+	offsetX, offsetY = BoardOrigin(screenW, screenH)
+	drawGameAt(b, state, hint, display, offsetX, offsetY)
+	return offsetX, offsetY
+}
+
+// DrawGames draws the games side by side into ScreenBuffer and returns the
+// column of each board's left edge and the row of their top edge. When the
+// screen is too narrow for every next queue, such as two games on 80 columns,
+// the rightmost game is drawn without one.
+func DrawGames(states []*GameState, screenW, screenH int) (xs []int, y int) {
+	return DrawGamesOn(screen(), states, screenW, screenH)
+}
 
-	if offsetX < 0 {
-		offsetX = 0
+// DrawGamesOn draws the games into b as DrawGames does into ScreenBuffer.
+func DrawGamesOn(b *render.Buffer, states []*GameState, screenW, screenH int) (xs []int, y int) {
+	screenW, screenH = PrepareBuffer(b, screenW, screenH)
+
+	display, last := settings.Default(), settings.Default()
+	total := len(states)*GameWidth + (len(states)-1)*gameGap
+	if total > screenW && len(states) > 1 {
+		last.Preview = 0
+		total -= gameNext
 	}
-	if offsetY < 0 {
-		offsetY = 0
+	left := max((screenW-total)/2, 0) + GameLeft
+	y = boardTop(screenH)
+
+	for i, state := range states {
+		x := left + i*(GameWidth+gameGap)
+		if i == len(states)-1 {
+			display = last
+		}
+		drawGameAt(b, state, nil, display, x, y)
+		xs = append(xs, x)
 	}
+	return xs, y
+}
+
+// DrawGameAt draws a game into ScreenBuffer with its board's top-left corner
+// at x, y. The hold and score column extends GameLeft columns to the left of x.
+func DrawGameAt(state *GameState, x, y int) {
+	drawGameAt(ScreenBuffer, state, nil, settings.Default(), x, y)
+}
 
-	drawBox(ScreenBuffer, offsetX, offsetY, consts.BoardWidth+1, consts.VisibleHeight+2, lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")))
+// drawGameAt draws the board with its top-left corner at offsetX, offsetY,
+// together with the hold, score and next queue displays around it.
+func drawGameAt(b *render.Buffer, state *GameState, hint *Suggestion, display settings.Settings, offsetX, offsetY int) {
+	drawBox(b, offsetX, offsetY, consts.BoardWidth+1, consts.VisibleHeight+2, lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")))
 
 	visibleStart := consts.BoardHeight - consts.VisibleHeight
+	if state.concealed() {
+		drawUI(b, state, display, offsetX, offsetY)
+		return
+	}
 
 	for y := range consts.VisibleHeight {
 		boardRowIdx := visibleStart + y
@@ -57,21 +158,25 @@ func RenderGame(state *GameState, screenW, screenH int) string {
 				if col == "" {
 					col = lipgloss.Color("#888888")
 				}
-				drawBlock(ScreenBuffer, offsetX+1+x*2, offsetY+1+y, col)
+				drawBlock(b, offsetX+1+x*2, offsetY+1+y, col, display.Palette)
 			}
 		}
 	}
 
-	ghostY := state.GhostY
-	drawGhost(ScreenBuffer, state.CurrentPiece, state.CurrentX, ghostY, state.CurrentRotation, offsetX+1, offsetY+1, visibleStart)
-	drawTetromino(ScreenBuffer, state.CurrentPiece, state.CurrentX, state.CurrentY, state.CurrentRotation, offsetX+1, offsetY+1, visibleStart)
-	drawUI(ScreenBuffer, state, offsetX, offsetY)
+	if hint != nil && !state.GameOver {
+		drawHint(b, state, hint, display.Palette, offsetX+1, offsetY+1, visibleStart)
+	}
 
-	return ScreenBuffer.Render()
+	ghostY := state.GhostY
+	if display.Ghost {
+		drawGhost(b, state.CurrentPiece, state.CurrentX, ghostY, state.CurrentRotation, offsetX+1, offsetY+1, visibleStart)
+	}
+	drawTetromino(b, state.CurrentPiece, state.CurrentX, state.CurrentY, state.CurrentRotation, offsetX+1, offsetY+1, visibleStart, display.Palette)
+	drawUI(b, state, display, offsetX, offsetY)
 }
 
 // drawTetromino renders the current falling piece to the buffer.
-func drawTetromino(b *render.Buffer, piece tetromino.Tetromino, px, py, rot, offX, offY, visibleStart int) {
+func drawTetromino(b *render.Buffer, piece tetromino.Tetromino, px, py, rot, offX, offY, visibleStart int, palette string) {
 	mask := piece.Masks[rot]
 
 	for r := range 4 {
@@ -86,7 +191,7 @@ func drawTetromino(b *render.Buffer, piece tetromino.Tetromino, px, py, rot, off
 			if (rowBits & tetromino.Bitmask(1<<c)) != 0 {
 				boardX := px + c
 				if boardX >= 0 && boardX < consts.BoardWidth {
-					drawBlock(b, offX+boardX*2, screenY, piece.Color)
+					drawBlock(b, offX+boardX*2, screenY, piece.Color, palette)
 				}
 			}
 		}
@@ -117,12 +222,58 @@ func drawGhost(b *render.Buffer, piece tetromino.Tetromino, px, py, rot, offX, o
 	}
 }
 
-func drawBlock(b *render.Buffer, x, y int, color lipgloss.Color) {
-	style := lipgloss.NewStyle().Foreground(color)
+// drawHint outlines the recommended placement in the colour of the piece that
+// would be placed, and marks the hold box when the hint is to hold first.
+func drawHint(b *render.Buffer, state *GameState, hint *Suggestion, palette string, offX, offY, visibleStart int) {
+	piece := state.CurrentPiece
+	if hint.Hold {
+		held, _, _, ok := state.HoldPreview()
+		if !ok {
+			return
+		}
+		piece = held
+		writeString(b, offX-11, offY+5, "<hold>", lipgloss.NewStyle().Foreground(displayColor(piece.Color, palette)).Bold(true))
+	}
+
+	p := hint.Placement
+	mask := piece.Masks[p.Rotation]
+	style := lipgloss.NewStyle().Foreground(displayColor(piece.Color, palette))
+
+	for r := range 4 {
+		boardY := p.Y + r
+		if boardY < visibleStart {
+			continue
+		}
+		screenY := offY + (boardY - visibleStart)
+		for c := range 4 {
+			if (mask[r] & tetromino.Bitmask(1<<c)) != 0 {
+				boardX := p.X + c
+				if boardX >= 0 && boardX < consts.BoardWidth {
+					b.Set(offX+boardX*2, screenY, '[', style)
+					b.Set(offX+boardX*2+1, screenY, ']', style)
+				}
+			}
+		}
+	}
+}
+
+func drawBlock(b *render.Buffer, x, y int, color lipgloss.Color, palette string) {
+	style := lipgloss.NewStyle().Foreground(displayColor(color, palette))
 	b.Set(x, y, '█', style)
 	b.Set(x+1, y, '█', style)
 }
 
+// displayColor returns the colour a piece colour is drawn in with palette.
+// Other colours, such as garbage, are kept.
+func displayColor(c lipgloss.Color, palette string) lipgloss.Color {
+	if name, ok := tetromino.FromColor(c); ok {
+		if mapped, ok := settings.PieceColor(palette, name); ok {
+			return lipgloss.Color(mapped)
+		}
+	}
+	return c
+}
+
 func drawBox(b *render.Buffer, x, y, w, h int, style lipgloss.Style) {
 	b.Set(x, y, '┌', style)
 	widthChars := w * 2
@@ -142,54 +293,165 @@ func drawBox(b *render.Buffer, x, y, w, h int, style lipgloss.Style) {
 }
 
 // drawUI renders score, level, hold, and next queue displays, plus game status overlays.
-func drawUI(b *render.Buffer, state *GameState, x, y int) {
+func drawUI(b *render.Buffer, state *GameState, display settings.Settings, x, y int) {
 	style := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
 
 	writeString(b, x-10, y, "Hold:", style)
-	if state.HoldPiece != nil {
-		drawMiniPiece(b, *state.HoldPiece, x-10, y+2)
+	if state.HoldPiece != nil && !state.concealed() {
+		drawMiniPiece(b, *state.HoldPiece, x-10, y+2, display.Palette)
 	}
 
 	writeString(b, x-10, y+8, "Score:", style)
 	writeString(b, x-10, y+9, fmt.Sprintf("%d", state.Score), style)
 
 	writeString(b, x-10, y+11, fmt.Sprintf("Lvl: %d", state.Level), style)
-	writeString(b, x-10, y+13, fmt.Sprintf("Lns: %d", state.LinesCleared), style)
+	switch state.Mode {
+	case ModeSprint:
+		writeString(b, x-10, y+13, fmt.Sprintf("Lns: %d/%d", state.LinesCleared, state.Goal()), style)
+		writeString(b, x-10, y+17, "Time:", style)
+		writeString(b, x-10, y+18, FormatTime(state.Elapsed), style)
+	case ModeUltra:
+		writeString(b, x-10, y+13, fmt.Sprintf("Lns: %d", state.LinesCleared), style)
+		writeString(b, x-10, y+17, "Left:", style)
+		writeString(b, x-10, y+18, FormatTime(max(UltraTime-state.Elapsed, 0)), style)
+	default:
+		writeString(b, x-10, y+13, fmt.Sprintf("Lns: %d", state.LinesCleared), style)
+	}
+
+	// Incoming garbage meter along the left edge of the board.
+	pending := min(state.PendingLines(), consts.VisibleHeight)
+	for i := range pending {
+		b.Set(x-1, y+consts.VisibleHeight-i, '▌', lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")))
+	}
+
+	if state.FinesseFlash > 0 {
+		writeString(b, x-10, y+15, "FINESSE", lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")).Bold(true))
+	}
 
-	writeString(b, x+24, y, "Next:", style)
+	if len(state.NextQueue) > 0 && display.Preview > 0 {
+		writeString(b, x+24, y, "Next:", style)
+	}
 	for i, piece := range state.NextQueue {
-		if i > 2 {
+		if i >= display.Preview || state.concealed() {
 			break
 		}
-		drawMiniPiece(b, piece, x+24, y+2+i*4)
+		drawMiniPiece(b, piece, x+24, y+2+i*4, display.Palette)
 	}
 
 	if state.GameOver {
 		b.DimArea(x+1, y+1, consts.BoardWidth*2, consts.VisibleHeight)
-		writeString(b, x+6, y+8, "GAME OVER", lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")).Bold(true))
+		if state.Completed {
+			writeString(b, x+6, y+8, "FINISHED!", lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true))
+		} else {
+			writeString(b, x+6, y+8, "GAME OVER", lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")).Bold(true))
+		}
 		writeString(b, x+6, y+10, "Press 'r'", style)
 		writeString(b, x+7, y+11, "to Retry", style)
+		if state.FinessePieces > 0 {
+			writeString(b, x+4, y+13, fmt.Sprintf("Finesse: %3.0f%%", state.FinessePercent()), style)
+		}
 	} else if state.Paused {
 		b.DimArea(x+1, y+1, consts.BoardWidth*2, consts.VisibleHeight)
 		writeString(b, x+8, y+9, "PAUSED", lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFF00")).Bold(true))
 	}
 }
 
+// Widths of the stats panel's layouts: labels beside their values, or above
+// them when the screen is too narrow for that.
+const (
+	statsWideWidth   = 14
+	statsNarrowWidth = 8
+)
+
+// drawStats draws the live stats panel to the right of the next queue of the
+// board at x, y. It is left out when the screen has no room for it.
+func drawStats(b *render.Buffer, state *GameState, x, y int) {
+	left := x + 34
+	room := b.Width() - left
+	if room < statsNarrowWidth {
+		return
+	}
+	wide := room >= statsWideWidth
+	width := statsNarrowWidth
+	if wide {
+		width = statsWideWidth
+	}
+
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	row := y
+	line := func(text string, style lipgloss.Style) {
+		writeString(b, left, row, text, style)
+		row++
+	}
+
+	line("Stats:", style)
+	row++
+	for _, stat := range []struct{ label, value string }{
+		{"Time", FormatTime(state.Elapsed)},
+		{"PPS", fmt.Sprintf("%.2f", state.PPS())},
+		{"APM", fmt.Sprintf("%.1f", state.APM())},
+		{"KPP", fmt.Sprintf("%.2f", state.KPP())},
+		{"Pieces", fmt.Sprint(state.PiecesPlaced)},
+		{"Combo", fmt.Sprint(max(state.Combo-1, 0))},
+		{"B2B", fmt.Sprint(state.Stats.B2BChain)},
+	} {
+		if wide {
+			line(fmt.Sprintf("%-*s%*s", width-len(stat.value), stat.label, len(stat.value), stat.value), style)
+		} else {
+			line(stat.label, dim)
+			line(stat.value, style)
+		}
+	}
+
+	if name := state.Stats.Last.Name(); name != "" {
+		row++
+		line("Last:", dim)
+		for _, text := range wrapWords(name, width) {
+			line(text, lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFF00")).Bold(true))
+		}
+	}
+}
+
+// wrapWords splits text into lines of at most width characters, breaking
+// between words.
+func wrapWords(text string, width int) []string {
+	var lines []string
+	for _, word := range strings.Fields(text) {
+		if n := len(lines); n > 0 && len(lines[n-1])+1+len(word) <= width {
+			lines[n-1] += " " + word
+		} else {
+			lines = append(lines, word)
+		}
+	}
+	return lines
+}
+
+// DrawText writes text into ScreenBuffer.
+func DrawText(x, y int, text string, style lipgloss.Style) {
+	writeString(screen(), x, y, text, style)
+}
+
+// DrawTextOn writes text into b.
+func DrawTextOn(b *render.Buffer, x, y int, text string, style lipgloss.Style) {
+	writeString(b, x, y, text, style)
+}
+
 func writeString(b *render.Buffer, x, y int, text string, style lipgloss.Style) {
-	for i, r := range text {
+	for i, r := range []rune(text) {
 		b.Set(x+i, y, r, style)
 	}
 }
 
 // drawMiniPiece renders a 4x4 tetromino piece for the hold and next queue display.
-func drawMiniPiece(b *render.Buffer, piece tetromino.Tetromino, x, y int) {
+func drawMiniPiece(b *render.Buffer, piece tetromino.Tetromino, x, y int, palette string) {
 	mask := piece.Masks[0]
 
 	for r := range 4 {
 		rowBits := mask[r]
 		for c := range 4 {
 			if (rowBits & tetromino.Bitmask(1<<c)) != 0 {
-				drawBlock(b, x+c*2, y+r, piece.Color)
+				drawBlock(b, x+c*2, y+r, piece.Color, palette)
 			}
 		}
 	}
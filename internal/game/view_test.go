@@ -0,0 +1,62 @@
+package game
+
+import (
+	"testing"
+
+	"termino/internal/render"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// playFrame advances m by a frame, hard dropping every 20 frames and starting
+// over after a top out.
+func playFrame(m Model, frame int) Model {
+	next, _ := m.Update(tickMsg{})
+	m = next.(Model)
+	if frame%20 == 19 {
+		m.apply(ActionHardDrop)
+	}
+	if m.State.GameOver {
+		m.RestartWithSeed(1)
+	}
+	return m
+}
+
+// BenchmarkModelView measures, per frame of a game in progress, the screen
+// the game hands to Bubbletea from View, and the output RenderDiff would
+// write for the same frames, as `termino watch -stream` does.
+func BenchmarkModelView(b *testing.B) {
+	old := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	b.Cleanup(func() { lipgloss.SetColorProfile(old) })
+
+	start := func() Model {
+		m := NewModelWithSeed(1)
+		m.Screen = render.NewBuffer(m.Width, m.Height)
+		return tick(m, countdownFrames)
+	}
+
+	b.Run("view", func(b *testing.B) {
+		b.ReportAllocs()
+		m := start()
+		bytes := 0
+		for i := 0; i < b.N; i++ {
+			m = playFrame(m, i)
+			bytes += len(m.View())
+		}
+		b.ReportMetric(float64(bytes)/float64(b.N), "bytes/frame")
+	})
+
+	b.Run("diff", func(b *testing.B) {
+		b.ReportAllocs()
+		m := start()
+		bytes := 0
+		for i := 0; i < b.N; i++ {
+			m = playFrame(m, i)
+			m.View()
+			bytes += len(m.Screen.RenderDiff())
+		}
+		b.ReportMetric(float64(bytes)/float64(b.N), "bytes/frame")
+	})
+}
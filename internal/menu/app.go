@@ -0,0 +1,186 @@
+// Package menu is the title menu and the screens it leads to. Screens are
+// ordinary Bubbletea models kept on a stack: each quits to return to the
+// screen below it.
+package menu
+
+import (
+	"reflect"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// App is a stack of screens. Keys go to the screen on top. When a screen
+// quits it is closed and the one below it is shown again; closing the last
+// screen quits the program.
+type App struct {
+	Closed  func(screen tea.Model) // Called with every screen once it is closed, e.g. to save its results
+	screens []screen
+	nextID  int
+	size    *tea.WindowSizeMsg // Last size seen, passed on to screens as they open
+}
+
+type screen struct {
+	id    int
+	model tea.Model
+}
+
+// screenMsg is the result of a command returned by the screen with id.
+// Results for screens that have since closed, such as their next tick, are
+// dropped.
+type screenMsg struct {
+	id  int
+	msg tea.Msg
+}
+
+// openMsg asks for a screen to be opened over the screen that sent it.
+type openMsg struct {
+	model   tea.Model
+	replace bool // Close the sender first
+}
+
+// Open returns a command that opens m over the current screen.
+func Open(m tea.Model) tea.Cmd {
+	return func() tea.Msg { return openMsg{model: m} }
+}
+
+// Replace returns a command that closes the current screen and opens m in
+// its place, so that quitting m returns to the screen below.
+func Replace(m tea.Model) tea.Cmd {
+	return func() tea.Msg { return openMsg{model: m, replace: true} }
+}
+
+// New creates an app showing root.
+func New(root tea.Model) App {
+	return App{screens: []screen{{id: 0, model: root}}, nextID: 1}
+}
+
+// Top returns the screen being shown.
+func (a App) Top() tea.Model {
+	return a.screens[len(a.screens)-1].model
+}
+
+// Push opens m over the current screen before the program starts.
+func (a *App) Push(m tea.Model) {
+	a.screens = append(a.screens, screen{id: a.nextID, model: m})
+	a.nextID++
+}
+
+func (a App) Init() tea.Cmd {
+	cmds := make([]tea.Cmd, len(a.screens))
+	for i, s := range a.screens {
+		cmds[i] = tag(s.id, s.model.Init())
+	}
+	return tea.Batch(cmds...)
+}
+
+func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	a.screens = slices.Clone(a.screens)
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		a.size = &msg
+		cmds := make([]tea.Cmd, len(a.screens))
+		for i := range a.screens {
+			cmds[i] = a.update(i, msg)
+		}
+		return a, tea.Batch(cmds...)
+
+	case tea.KeyMsg:
+		cmd := a.update(len(a.screens)-1, msg)
+		if msg.String() == "ctrl+c" {
+			// Give the screen on top a chance to save, then leave.
+			a.close(0)
+			return a, tea.Quit
+		}
+		return a, cmd
+
+	case screenMsg:
+		i := slices.IndexFunc(a.screens, func(s screen) bool { return s.id == msg.id })
+		if i < 0 || msg.msg == nil {
+			return a, nil
+		}
+		switch inner := msg.msg.(type) {
+		case tea.QuitMsg:
+			a.close(i)
+			if len(a.screens) == 0 {
+				return a, tea.Quit
+			}
+			return a, nil
+		case tea.BatchMsg:
+			cmds := make([]tea.Cmd, len(inner))
+			for j, cmd := range inner {
+				cmds[j] = tag(msg.id, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		case openMsg:
+			if inner.replace {
+				a.close(i)
+			}
+			return a, a.open(inner.model)
+		}
+		if isProgramMsg(msg.msg) {
+			// Requests to the program itself, like suspending, go through
+			// as they are.
+			return a, func() tea.Msg { return msg.msg }
+		}
+		return a, a.update(i, msg.msg)
+	}
+	// Anything else, such as the reply to a program request, goes to the
+	// screen on top.
+	return a, a.update(len(a.screens)-1, msg)
+}
+
+func (a App) View() string {
+	if len(a.screens) == 0 {
+		return ""
+	}
+	return a.Top().View()
+}
+
+// update passes msg to the screen at i.
+func (a *App) update(i int, msg tea.Msg) tea.Cmd {
+	if i < 0 {
+		return nil
+	}
+	s := &a.screens[i]
+	next, cmd := s.model.Update(msg)
+	s.model = next
+	return tag(s.id, cmd)
+}
+
+// open pushes m and starts it at the current screen size.
+func (a *App) open(m tea.Model) tea.Cmd {
+	a.Push(m)
+	cmds := []tea.Cmd{tag(a.nextID-1, m.Init())}
+	if a.size != nil {
+		cmds = append(cmds, a.update(len(a.screens)-1, *a.size))
+	}
+	return tea.Batch(cmds...)
+}
+
+// close removes the screen at i and every screen above it, newest first.
+func (a *App) close(i int) {
+	for j := len(a.screens) - 1; j >= i; j-- {
+		if a.Closed != nil {
+			a.Closed(a.screens[j].model)
+		}
+	}
+	a.screens = a.screens[:i]
+}
+
+// tag marks the result of cmd as coming from the screen with id.
+func tag(id int, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return screenMsg{id: id, msg: cmd()}
+	}
+}
+
+// isProgramMsg reports whether msg is addressed to the Bubbletea program
+// rather than to a screen.
+func isProgramMsg(msg tea.Msg) bool {
+	t := reflect.TypeOf(msg)
+	return t != nil && t.PkgPath() == reflect.TypeOf(tea.QuitMsg{}).PkgPath()
+}
@@ -0,0 +1,100 @@
+package menu
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	textStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	titleStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFF00")).Bold(true)
+	selectedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#00FFFF")).Bold(true)
+	dimStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000"))
+)
+
+// Item is an entry of a List.
+type Item struct {
+	Label string
+	Open  func() (tea.Model, error) // Screen shown when the item is chosen; nil closes the list
+}
+
+// List is a screen of items chosen with the arrow keys and enter.
+type List struct {
+	Title  string
+	Items  []Item
+	Width  int
+	Height int
+	Err    error // Why the last item chosen could not be opened
+	cursor int
+}
+
+// NewList creates a list of items under title.
+func NewList(title string, items ...Item) List {
+	return List{Title: title, Items: items, Width: 80, Height: 24}
+}
+
+func (l List) Init() tea.Cmd {
+	return nil
+}
+
+func (l List) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		l.Width, l.Height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			l.cursor = (l.cursor + len(l.Items) - 1) % len(l.Items)
+		case "down", "j":
+			l.cursor = (l.cursor + 1) % len(l.Items)
+		case "enter", " ":
+			open := l.Items[l.cursor].Open
+			if open == nil {
+				return l, tea.Quit
+			}
+			m, err := open()
+			l.Err = err
+			if err != nil {
+				return l, nil
+			}
+			return l, Open(m)
+		case "esc", "q":
+			return l, tea.Quit
+		}
+	}
+	return l, nil
+}
+
+func (l List) View() string {
+	lines := []string{titleStyle.Render(l.Title), ""}
+	for i, item := range l.Items {
+		if i == l.cursor {
+			lines = append(lines, selectedStyle.Render("> "+item.Label))
+		} else {
+			lines = append(lines, textStyle.Render("  "+item.Label))
+		}
+	}
+	lines = append(lines, "", dimStyle.Render("↑/↓ choose   enter select   esc back"))
+	if l.Err != nil {
+		lines = append(lines, "", errorStyle.Render(l.Err.Error()))
+	}
+	return place(lines, l.Width, l.Height)
+}
+
+// place centres lines on a screen of w by h.
+func place(lines []string, w, h int) string {
+	block := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	if w == 0 || h == 0 {
+		return block
+	}
+	return lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center, block)
+}
+
+// banner is the title screen's logo.
+var banner = strings.Join([]string{
+	"▀█▀ █▀▀ █▀█ █▀▄▀█ █ █▄ █ █▀█",
+	" █  ██▄ █▀▄ █ ▀ █ █ █ ▀█ █▄█",
+}, "\n")
@@ -0,0 +1,169 @@
+package menu
+
+import (
+	"testing"
+
+	"termino/internal/game"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pingMsg is a message a fake screen sends itself.
+type pingMsg struct{}
+
+// fakeScreen opens next on 'o', quits on 'q', sends itself a ping on 'x'
+// and counts the pings it receives.
+type fakeScreen struct {
+	name  string
+	next  tea.Model
+	pings int
+}
+
+func (f fakeScreen) Init() tea.Cmd { return nil }
+
+func (f fakeScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "o":
+			return f, Open(f.next)
+		case "q":
+			return f, tea.Quit
+		case "x":
+			return f, func() tea.Msg { return pingMsg{} }
+		}
+	case pingMsg:
+		f.pings++
+	}
+	return f, nil
+}
+
+func (f fakeScreen) View() string { return f.name }
+
+func key(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case "right":
+		return tea.KeyMsg{Type: tea.KeyRight}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+// run runs cmd and feeds the messages it produces back to a, as the program
+// would, returning the messages meant for the program itself.
+func run(a App, cmd tea.Cmd) (App, []tea.Msg) {
+	if cmd == nil {
+		return a, nil
+	}
+	var out []tea.Msg
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			var more []tea.Msg
+			a, more = run(a, c)
+			out = append(out, more...)
+		}
+	case tea.QuitMsg:
+		out = append(out, msg)
+	default:
+		next, cmd := a.Update(msg)
+		var more []tea.Msg
+		a, more = run(next.(App), cmd)
+		out = append(out, more...)
+	}
+	return a, out
+}
+
+func pressKeys(a App, keys ...string) (App, []tea.Msg) {
+	var out []tea.Msg
+	for _, k := range keys {
+		next, cmd := a.Update(key(k))
+		var more []tea.Msg
+		a, more = run(next.(App), cmd)
+		out = append(out, more...)
+	}
+	return a, out
+}
+
+func TestApp_QuitReturnsToScreenBelow(t *testing.T) {
+	var closed []string
+	a := New(fakeScreen{name: "title", next: fakeScreen{name: "game"}})
+	a.Closed = func(m tea.Model) { closed = append(closed, m.View()) }
+
+	a, _ = pressKeys(a, "o")
+	if a.View() != "game" {
+		t.Fatalf("View = %q after opening, want game", a.View())
+	}
+	a, out := pressKeys(a, "q")
+	if a.View() != "title" || len(out) != 0 {
+		t.Fatalf("View = %q, program messages %v after quitting the game, want title and none", a.View(), out)
+	}
+	if len(closed) != 1 || closed[0] != "game" {
+		t.Errorf("Closed called with %v, want [game]", closed)
+	}
+
+	_, out = pressKeys(a, "q")
+	if len(out) != 1 {
+		t.Errorf("Program messages %v after quitting the last screen, want a quit", out)
+	}
+}
+
+func TestApp_DropsMessagesForClosedScreens(t *testing.T) {
+	a := New(fakeScreen{name: "title", next: fakeScreen{name: "game"}})
+	a, _ = pressKeys(a, "o")
+
+	// The game's ping arrives after it has been closed.
+	next, ping := a.Update(key("x"))
+	a, _ = pressKeys(next.(App), "q")
+	a, _ = run(a, ping)
+
+	if top := a.Top().(fakeScreen); top.pings != 0 {
+		t.Errorf("Title received %d pings meant for the game", top.pings)
+	}
+
+	next, ping = a.Update(key("x"))
+	a, _ = run(next.(App), ping)
+	if top := a.Top().(fakeScreen); top.pings != 1 {
+		t.Errorf("Title received %d of its own pings, want 1", top.pings)
+	}
+}
+
+func TestSetupScreen_StartsConfiguredGame(t *testing.T) {
+	var started []game.Setup
+	start := func(s game.Setup) (tea.Model, error) {
+		started = append(started, s)
+		return fakeScreen{name: "game"}, nil
+	}
+	a := New(fakeScreen{name: "title", next: NewSetupScreen(game.Setup{Mode: game.ModeSprint}, start)})
+
+	// Level 3, seed 42, 100 lines.
+	a, _ = pressKeys(a, "o", "right", "right", "down", "4", "x", "2", "down", "right", "enter")
+
+	want := game.Setup{Mode: game.ModeSprint, Seed: 42, Level: 3, LineGoal: 100}
+	if len(started) != 1 || started[0] != want {
+		t.Fatalf("Started %+v, want %+v", started, want)
+	}
+	if a.View() != "game" {
+		t.Fatalf("View = %q, want game", a.View())
+	}
+	// The game replaced the setup screen.
+	if a, _ = pressKeys(a, "q"); a.View() != "title" {
+		t.Errorf("View = %q after leaving the game, want title", a.View())
+	}
+}
+
+func TestSetupScreen_StandardSprintGoal(t *testing.T) {
+	var started game.Setup
+	s := NewSetupScreen(game.Setup{Mode: game.ModeSprint}, func(s game.Setup) (tea.Model, error) {
+		started = s
+		return fakeScreen{}, nil
+	})
+	s.Update(key("enter"))
+
+	if started.LineGoal != 0 || started.Seed != 0 || started.Level != 1 {
+		t.Errorf("Started %+v, want the default goal and a random seed", started)
+	}
+}
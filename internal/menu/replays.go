@@ -0,0 +1,123 @@
+package menu
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"termino/internal/game"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// replayRows is how many replays the replays screen lists at once.
+const replayRows = 12
+
+// ReplayFile is a replay kept in the replay directory.
+type ReplayFile struct {
+	Path   string
+	Date   time.Time
+	Replay *game.Replay
+}
+
+// ListReplays returns the replays in dir, newest first. Files that cannot be
+// read are left out.
+func ListReplays(dir string) ([]ReplayFile, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var files []ReplayFile
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		r, err := game.LoadReplay(path)
+		if err != nil {
+			continue
+		}
+		files = append(files, ReplayFile{Path: path, Date: info.ModTime(), Replay: r})
+	}
+	slices.SortFunc(files, func(a, b ReplayFile) int {
+		return b.Date.Compare(a.Date)
+	})
+	return files, nil
+}
+
+// Replays lists the replays of recent games and plays the one chosen.
+type Replays struct {
+	Files  []ReplayFile
+	Width  int
+	Height int
+	cursor int
+}
+
+// NewReplays lists the replays in dir.
+func NewReplays(dir string) (Replays, error) {
+	files, err := ListReplays(dir)
+	if err != nil {
+		return Replays{}, err
+	}
+	return Replays{Files: files, Width: 80, Height: 24}, nil
+}
+
+func (r Replays) Init() tea.Cmd {
+	return nil
+}
+
+func (r Replays) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		r.Width, r.Height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			r.cursor = max(r.cursor-1, 0)
+		case "down", "j":
+			r.cursor = min(r.cursor+1, max(len(r.Files)-1, 0))
+		case "enter", " ":
+			if len(r.Files) > 0 {
+				return r, Open(game.NewReplayPlayer(r.Files[r.cursor].Replay))
+			}
+		case "esc", "q":
+			return r, tea.Quit
+		}
+	}
+	return r, nil
+}
+
+func (r Replays) View() string {
+	lines := []string{titleStyle.Render("REPLAYS"), ""}
+	if len(r.Files) == 0 {
+		lines = append(lines, textStyle.Render("No replays yet. Finished games are kept here."))
+	}
+	first := max(min(r.cursor-replayRows/2, len(r.Files)-replayRows), 0)
+	for i := first; i < min(first+replayRows, len(r.Files)); i++ {
+		f := r.Files[i]
+		mode := f.Replay.Mode
+		if mode == "" {
+			mode = game.ModeMarathon
+		}
+		length := game.FormatTime(time.Duration(f.Replay.Frames) * time.Second / 60)
+		line := fmt.Sprintf("%s  %-9s %9s", f.Date.Format("2006-01-02 15:04"), strings.ToUpper(string(mode)), length)
+		if i == r.cursor {
+			lines = append(lines, selectedStyle.Render("> "+line))
+		} else {
+			lines = append(lines, textStyle.Render("  "+line))
+		}
+	}
+	lines = append(lines, "", dimStyle.Render("↑/↓ choose   enter watch   esc back"))
+	return place(lines, r.Width, r.Height)
+}
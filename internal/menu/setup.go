@@ -0,0 +1,178 @@
+package menu
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"termino/internal/game"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// MaxStartLevel is the highest level a game can start at.
+const MaxStartLevel = 15
+
+// SprintGoals are the line goals offered for sprint.
+var SprintGoals = []int{20, game.SprintLines, 100}
+
+// setupField is a line of the setup screen.
+type setupField int
+
+const (
+	fieldLevel setupField = iota
+	fieldSeed
+	fieldLines
+	fieldStart
+)
+
+// SetupScreen configures a game before starting it: its starting level, a
+// seed to replay a known piece sequence, and the line goal of a sprint.
+type SetupScreen struct {
+	Setup  game.Setup
+	Width  int
+	Height int
+	Err    error // Why the game could not be started
+	start  func(s game.Setup) (tea.Model, error)
+	seed   string // Digits typed so far, empty for a random seed
+	cursor int
+}
+
+// NewSetupScreen configures a game starting from s, started with start.
+func NewSetupScreen(s game.Setup, start func(s game.Setup) (tea.Model, error)) SetupScreen {
+	if s.Level < 1 {
+		s.Level = 1
+	}
+	if s.Mode == game.ModeSprint && s.LineGoal == 0 {
+		s.LineGoal = game.SprintLines
+	}
+	setup := SetupScreen{Setup: s, Width: 80, Height: 24, start: start}
+	if s.Seed != 0 {
+		setup.seed = strconv.FormatInt(s.Seed, 10)
+	}
+	return setup
+}
+
+// fields returns the lines shown for the mode being set up.
+func (s SetupScreen) fields() []setupField {
+	if s.Setup.Mode == game.ModeSprint {
+		return []setupField{fieldLevel, fieldSeed, fieldLines, fieldStart}
+	}
+	return []setupField{fieldLevel, fieldSeed, fieldStart}
+}
+
+func (s SetupScreen) Init() tea.Cmd {
+	return nil
+}
+
+func (s SetupScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.Width, s.Height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		fields := s.fields()
+		switch msg.String() {
+		case "up", "k":
+			s.cursor = (s.cursor + len(fields) - 1) % len(fields)
+		case "down", "j", "tab":
+			s.cursor = (s.cursor + 1) % len(fields)
+		case "left", "h":
+			s.adjust(fields[s.cursor], -1)
+		case "right", "l":
+			s.adjust(fields[s.cursor], 1)
+		case "backspace":
+			if fields[s.cursor] == fieldSeed && s.seed != "" {
+				s.seed = s.seed[:len(s.seed)-1]
+			}
+		case "enter":
+			return s.play()
+		case "esc", "q":
+			return s, tea.Quit
+		default:
+			if fields[s.cursor] == fieldSeed {
+				s.typeSeed(msg)
+			}
+		}
+	}
+	return s, nil
+}
+
+// adjust changes the value of field by one step in dir.
+func (s *SetupScreen) adjust(field setupField, dir int) {
+	switch field {
+	case fieldLevel:
+		s.Setup.Level = min(max(s.Setup.Level+dir, 1), MaxStartLevel)
+	case fieldLines:
+		n := len(SprintGoals)
+		i := max(slices.Index(SprintGoals, s.Setup.LineGoal), 0)
+		s.Setup.LineGoal = SprintGoals[(i+dir+n)%n]
+	}
+}
+
+// typeSeed adds the digits of key to the seed, as long as it still fits.
+func (s *SetupScreen) typeSeed(key tea.KeyMsg) {
+	if key.Type != tea.KeyRunes {
+		return
+	}
+	for _, r := range key.Runes {
+		if r < '0' || r > '9' {
+			continue
+		}
+		seed := strings.TrimLeft(s.seed+string(r), "0")
+		if _, err := strconv.ParseInt(seed, 10, 64); err == nil || seed == "" {
+			s.seed = seed
+		}
+	}
+}
+
+// play replaces the setup screen with the game, so that leaving the game
+// returns to the title menu.
+func (s SetupScreen) play() (tea.Model, tea.Cmd) {
+	setup := s.Setup
+	setup.Seed, _ = strconv.ParseInt(s.seed, 10, 64)
+	if setup.Mode == game.ModeSprint && setup.LineGoal == game.SprintLines {
+		setup.LineGoal = 0
+	}
+	m, err := s.start(setup)
+	s.Err = err
+	if err != nil {
+		return s, nil
+	}
+	return s, Replace(m)
+}
+
+func (s SetupScreen) View() string {
+	lines := []string{titleStyle.Render(strings.ToUpper(string(s.Setup.Mode))), ""}
+	for i, field := range s.fields() {
+		var line string
+		switch field {
+		case fieldLevel:
+			line = fmt.Sprintf("Level   < %2d >", s.Setup.Level)
+		case fieldSeed:
+			seed := s.seed
+			if seed == "" {
+				seed = "random"
+			}
+			if i == s.cursor {
+				seed += "_"
+			}
+			line = "Seed    " + seed
+		case fieldLines:
+			line = fmt.Sprintf("Lines   < %3d >", s.Setup.LineGoal)
+		case fieldStart:
+			line = "Start"
+			lines = append(lines, "")
+		}
+		if i == s.cursor {
+			lines = append(lines, selectedStyle.Render("> "+line))
+		} else {
+			lines = append(lines, textStyle.Render("  "+line))
+		}
+	}
+	lines = append(lines, "", dimStyle.Render("←/→ change   0-9 seed   enter play   esc back"))
+	if s.Err != nil {
+		lines = append(lines, "", errorStyle.Render(s.Err.Error()))
+	}
+	return place(lines, s.Width, s.Height)
+}
@@ -0,0 +1,60 @@
+package menu
+
+import (
+	"strings"
+
+	"termino/internal/bot"
+	"termino/internal/game"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Options builds the screens the title menu leads to. They are supplied by
+// the caller, which knows where their files are kept.
+type Options struct {
+	Play      func(s game.Setup) (tea.Model, error)
+	Practice  func() (tea.Model, error)
+	Versus    func(cpu string) (tea.Model, error) // cpu is a bot strength, or empty for two players on one keyboard
+	Scores    func() (tea.Model, error)
+	Settings  func() (tea.Model, error)
+	ReplayDir string // Directory of the replays listed by the replays screen
+}
+
+// NewTitle creates the title menu.
+func NewTitle(opts Options) List {
+	var items []Item
+	for _, mode := range game.Modes {
+		items = append(items, Item{
+			Label: "Play " + strings.ToUpper(string(mode)[:1]) + string(mode)[1:],
+			Open: func() (tea.Model, error) {
+				return NewSetupScreen(game.Setup{Mode: mode}, opts.Play), nil
+			},
+		})
+	}
+	items = append(items,
+		Item{Label: "Practice", Open: opts.Practice},
+		Item{Label: "Versus", Open: func() (tea.Model, error) {
+			return newVersusList(opts.Versus), nil
+		}},
+		Item{Label: "Replays", Open: func() (tea.Model, error) {
+			return NewReplays(opts.ReplayDir)
+		}},
+		Item{Label: "High scores", Open: opts.Scores},
+		Item{Label: "Settings", Open: opts.Settings},
+		Item{Label: "Quit"},
+	)
+	return NewList(banner, items...)
+}
+
+// newVersusList offers a match against a second player or each bot strength.
+func newVersusList(versus func(cpu string) (tea.Model, error)) List {
+	items := []Item{{Label: "Two players", Open: func() (tea.Model, error) { return versus("") }}}
+	for _, s := range bot.Strengths {
+		items = append(items, Item{
+			Label: "CPU (" + s.Name + ")",
+			Open:  func() (tea.Model, error) { return versus(s.Name) },
+		})
+	}
+	items = append(items, Item{Label: "Back"})
+	return NewList("VERSUS", items...)
+}
@@ -0,0 +1,139 @@
+package netplay
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// handshakeTimeout bounds the exchange of hello and start messages.
+const handshakeTimeout = 10 * time.Second
+
+// Conn is an established connection to the opponent.
+type Conn struct {
+	Seed int64 // Seed of the match, chosen by the host
+
+	conn     net.Conn
+	r        *bufio.Reader
+	w        *bufio.Writer
+	mu       sync.Mutex // Serialises writes
+	incoming chan message
+	err      error         // Why incoming was closed
+	done     chan struct{} // Closed by Close to stop the reader
+	once     sync.Once
+}
+
+// Host waits for one opponent on l and starts a match with seed.
+func Host(l net.Listener, seed int64) (*Conn, error) {
+	nc, err := l.Accept()
+	if err != nil {
+		return nil, err
+	}
+	c := newConn(nc)
+	if err := c.handshake(true, seed); err != nil {
+		nc.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Join connects to a host at addr.
+func Join(addr string) (*Conn, error) {
+	nc, err := net.DialTimeout("tcp", addr, handshakeTimeout)
+	if err != nil {
+		return nil, err
+	}
+	c := newConn(nc)
+	if err := c.handshake(false, 0); err != nil {
+		nc.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func newConn(nc net.Conn) *Conn {
+	return &Conn{
+		conn:     nc,
+		r:        bufio.NewReader(nc),
+		w:        bufio.NewWriter(nc),
+		incoming: make(chan message, 1024),
+		done:     make(chan struct{}),
+	}
+}
+
+// handshake exchanges versions and, from the host, the seed, then starts
+// reading messages in the background.
+func (c *Conn) handshake(host bool, seed int64) error {
+	c.conn.SetDeadline(time.Now().Add(handshakeTimeout))
+	defer c.conn.SetDeadline(time.Time{})
+
+	if err := c.send(message{Type: msgHello, Version: Version}); err != nil {
+		return err
+	}
+	hello, err := readMessage(c.r)
+	if err != nil {
+		return err
+	}
+	if hello.Type != msgHello {
+		return fmt.Errorf("netplay: expected hello, got message type %d", hello.Type)
+	}
+	if hello.Version != Version {
+		return fmt.Errorf("%w: peer has %d, we have %d", ErrVersion, hello.Version, Version)
+	}
+
+	if host {
+		c.Seed = seed
+		if err := c.send(message{Type: msgStart, Seed: seed}); err != nil {
+			return err
+		}
+	} else {
+		start, err := readMessage(c.r)
+		if err != nil {
+			return err
+		}
+		if start.Type != msgStart {
+			return fmt.Errorf("netplay: expected start, got message type %d", start.Type)
+		}
+		c.Seed = start.Seed
+	}
+
+	go c.readLoop()
+	return nil
+}
+
+// readLoop passes messages to incoming until the connection fails or is
+// closed. It gives up on a message nobody reads once Close is called.
+func (c *Conn) readLoop() {
+	defer close(c.incoming)
+	for {
+		m, err := readMessage(c.r)
+		if err != nil {
+			c.err = err
+			return
+		}
+		select {
+		case c.incoming <- m:
+		case <-c.done:
+			c.err = net.ErrClosed
+			return
+		}
+	}
+}
+
+func (c *Conn) send(m message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := writeMessage(c.w, m); err != nil {
+		return err
+	}
+	return c.w.Flush()
+}
+
+// Close ends the connection and stops the background reader. It is safe to
+// call more than once.
+func (c *Conn) Close() error {
+	c.once.Do(func() { close(c.done) })
+	return c.conn.Close()
+}
@@ -0,0 +1,283 @@
+package netplay
+
+import (
+	"fmt"
+	"time"
+
+	"termino/internal/game"
+	"termino/pkg/consts"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Timing of the connection health checks.
+const (
+	pingInterval = consts.TickRate // Frames between pings
+	peerTimeout  = 5 * time.Second
+)
+
+type (
+	tickMsg      time.Time
+	netMsg       message
+	netClosedMsg struct{ err error }
+)
+
+// Match is a networked 1v1 game. Own is simulated immediately from the
+// keyboard; Opponent is a replica advanced only through frames the opponent
+// has confirmed, so it trails the real board by the connection latency.
+type Match struct {
+	Own      game.GameState
+	Opponent game.GameState
+	Width    int
+	Height   int
+
+	conn      *Conn
+	frame     int       // Own frames simulated
+	oppFrame  int       // Opponent frames replayed
+	confirmed int       // Opponent frames known to be complete
+	events    []message // Opponent events not yet replayed
+	ownOver   int       // Frame Own topped out on, or -1
+	oppOver   int       // Frame Opponent topped out on, or -1
+	ping      time.Duration
+	lastHeard time.Time
+	status    string // Shown below the boards once the match has ended
+	done      bool   // The result is known; both boards are frozen
+	closed    bool   // The connection is gone
+
+	lastSpacePressed bool
+}
+
+// NewMatch starts a match on an established connection.
+func NewMatch(c *Conn) Match {
+	return Match{
+		Own:       game.NewGameStateWithSeed(c.Seed),
+		Opponent:  game.NewGameStateWithSeed(c.Seed),
+		Width:     80,
+		Height:    24,
+		conn:      c,
+		ownOver:   -1,
+		oppOver:   -1,
+		lastHeard: time.Now(),
+	}
+}
+
+func (m Match) Init() tea.Cmd {
+	return tea.Batch(tick(), m.wait())
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(time.Second/consts.TickRate, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
+
+// wait delivers the next message from the opponent.
+func (m Match) wait() tea.Cmd {
+	c := m.conn
+	return func() tea.Msg {
+		msg, ok := <-c.incoming
+		if !ok {
+			return netClosedMsg{c.err}
+		}
+		return netMsg(msg)
+	}
+}
+
+func (m Match) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.Width = msg.Width
+		m.Height = msg.Height
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		}
+		if m.done || m.Own.GameOver {
+			return m, nil
+		}
+		action, ok := game.DefaultKeymap[msg.String()]
+		if !ok {
+			return m, nil
+		}
+		if action == game.ActionHardDrop {
+			if m.lastSpacePressed {
+				return m, nil
+			}
+			m.lastSpacePressed = true
+		}
+		m.Own.Apply(action)
+		index, _ := actionIndex(action)
+		m.send(message{Type: msgEvent, Frame: uint32(m.frame), Kind: eventInput, Value: index})
+		m.checkOver()
+
+	case tickMsg:
+		m.lastSpacePressed = false
+		if m.closed {
+			return m, nil
+		}
+		// Frames are still confirmed after the result is known, so that the
+		// opponent can reach the same result.
+		m.step()
+		if time.Since(m.lastHeard) > peerTimeout {
+			m.disconnect("Connection timed out")
+			m.conn.Close()
+		}
+		return m, tick()
+
+	case netMsg:
+		m.lastHeard = time.Now()
+		m.receive(message(msg))
+		return m, m.wait()
+
+	case netClosedMsg:
+		m.disconnect("Opponent disconnected")
+	}
+	return m, nil
+}
+
+// step simulates one frame of Own and confirms it to the opponent.
+func (m *Match) step() {
+	if !m.Own.GameOver && !m.done {
+		m.Own.ApplyGravity(1.0 / consts.TickRate)
+	}
+	// Own attack reaches the opponent through their replica of this board.
+	m.Own.Outgoing = 0
+	m.checkOver()
+
+	m.frame++
+	m.send(message{Type: msgFrame, Frame: uint32(m.frame)})
+	if m.frame%pingInterval == 0 {
+		m.send(message{Type: msgPing, Nanos: time.Now().UnixNano()})
+	}
+	m.decide()
+}
+
+// receive handles a message from the opponent.
+func (m *Match) receive(msg message) {
+	switch msg.Type {
+	case msgEvent:
+		m.events = append(m.events, msg)
+	case msgFrame:
+		m.confirmed = max(m.confirmed, int(msg.Frame))
+		m.replay()
+	case msgPing:
+		m.send(message{Type: msgPong, Nanos: msg.Nanos})
+	case msgPong:
+		m.ping = time.Since(time.Unix(0, msg.Nanos))
+	}
+}
+
+// replay advances the opponent's board through every confirmed frame,
+// applying each frame's events before its gravity as the opponent did.
+func (m *Match) replay() {
+	for m.oppFrame < m.confirmed {
+		for len(m.events) > 0 && int(m.events[0].Frame) == m.oppFrame {
+			e := m.events[0]
+			m.events = m.events[1:]
+			switch e.Kind {
+			case eventInput:
+				if int(e.Value) < len(actions) {
+					m.Opponent.Apply(actions[e.Value])
+				}
+			case eventGarbage:
+				m.Opponent.ReceiveGarbage(int(e.Value))
+			}
+		}
+		if !m.Opponent.GameOver && !m.done {
+			m.Opponent.ApplyGravity(1.0 / consts.TickRate)
+		}
+		if m.Opponent.GameOver && m.oppOver < 0 {
+			m.oppOver = m.oppFrame
+		}
+		m.oppFrame++
+
+		// The opponent's attack arrives on this board now.
+		for sent := m.Opponent.Outgoing; sent > 0 && !m.Own.GameOver && !m.done; {
+			lines := min(sent, 255)
+			sent -= lines
+			m.Own.ReceiveGarbage(lines)
+			m.send(message{Type: msgEvent, Frame: uint32(m.frame), Kind: eventGarbage, Value: uint8(lines)})
+		}
+		m.Opponent.Outgoing = 0
+	}
+	m.decide()
+}
+
+func (m *Match) checkOver() {
+	if m.Own.GameOver && m.ownOver < 0 {
+		m.ownOver = m.frame
+	}
+}
+
+// decide ends the match once both clients must agree on the result: whoever
+// topped out on the earlier frame loses, and the same frame is a draw.
+func (m *Match) decide() {
+	if m.done {
+		return
+	}
+	switch {
+	case m.ownOver >= 0 && m.oppOver >= 0:
+		switch {
+		case m.ownOver < m.oppOver:
+			m.end("YOU LOSE")
+		case m.ownOver > m.oppOver:
+			m.end("YOU WIN")
+		default:
+			m.end("DRAW")
+		}
+	case m.ownOver >= 0 && m.oppFrame > m.ownOver:
+		m.end("YOU LOSE")
+	case m.oppOver >= 0 && m.frame > m.oppOver && m.ownOver < 0:
+		m.end("YOU WIN")
+	}
+}
+
+func (m *Match) end(status string) {
+	m.status = status
+	m.done = true
+}
+
+// disconnect ends the match early, keeping a result that is already known.
+func (m *Match) disconnect(status string) {
+	m.closed = true
+	if !m.done {
+		m.end(status)
+	}
+}
+
+func (m *Match) send(msg message) {
+	if m.closed {
+		return
+	}
+	if err := m.conn.send(msg); err != nil {
+		m.disconnect("Opponent disconnected")
+	}
+}
+
+func (m Match) View() string {
+	states := []*game.GameState{&m.Own, &m.Opponent}
+	xs, y := game.DrawGames(states, m.Width, m.Height)
+
+	white := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	bold := white.Bold(true)
+	if y > 0 {
+		game.DrawText(xs[0]+10, y-1, "YOU", bold)
+		game.DrawText(xs[1]+6, y-1, "OPPONENT", bold)
+	}
+	game.DrawText(xs[1]+24, y+16, fmt.Sprintf("Ping: %dms", m.ping.Milliseconds()), white)
+	game.DrawText(xs[1]+24, y+17, fmt.Sprintf("Delay: %d", max(m.frame-m.oppFrame, 0)), white)
+
+	if m.status != "" {
+		col := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFF00")).Bold(true)
+		mid := (xs[0] + xs[1] + consts.BoardWidth*2 + 2) / 2
+		if y+consts.VisibleHeight+2 < m.Height {
+			game.DrawText(mid-len(m.status)/2, y+consts.VisibleHeight+2, m.status, col)
+		} else {
+			game.DrawText(mid-len(m.status)/2, y, m.status, col)
+		}
+	}
+	return game.ScreenBuffer.Render()
+}
@@ -0,0 +1,379 @@
+package netplay
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"termino/internal/game"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// The test binary doubles as the joining player when NETPLAY_PEER holds the
+// host's address.
+func TestMain(m *testing.M) {
+	if addr := os.Getenv("NETPLAY_PEER"); addr != "" {
+		os.Exit(peer(addr))
+	}
+	os.Exit(m.Run())
+}
+
+// Scripts played by the two processes of TestMatch_TwoProcesses, one input
+// every scriptPace frames, for matchFrames frames in total.
+var (
+	hostScript = []game.Action{game.ActionRotateCW, game.ActionRight, game.ActionRight, game.ActionHardDrop, game.ActionHold, game.ActionLeft, game.ActionHardDrop}
+	peerScript = []game.Action{game.ActionLeft, game.ActionLeft, game.ActionLeft, game.ActionHardDrop, game.ActionRotateCCW, game.ActionHardDrop, game.ActionSoftDrop}
+)
+
+const (
+	scriptPace  = 10
+	matchFrames = 120
+)
+
+// peer joins the host at addr, plays peerScript and prints its board and its
+// replica of the host's once both are complete.
+func peer(addr string) int {
+	c, err := Join(addr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer c.Close()
+
+	m, err := playScript(NewMatch(c), peerScript)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Print(summary(&m.Own), "--\n", summary(&m.Opponent))
+	os.Stdout.Sync()
+
+	// Stay connected until the host has read everything.
+	for range c.incoming {
+	}
+	return 0
+}
+
+// playScript plays script over matchFrames frames, then delivers messages
+// until the opponent's replica has reached the same frame.
+func playScript(m Match, script []game.Action) (Match, error) {
+	for frame := range matchFrames {
+		if i := frame / scriptPace; frame%scriptPace == 0 && i < len(script) {
+			m = update(m, actionKeys[script[i]])
+		}
+		m = deliver(update(m, tickMsg{}))
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for m.oppFrame < matchFrames {
+		if time.Now().After(deadline) {
+			return m, fmt.Errorf("opponent replica stuck at frame %d", m.oppFrame)
+		}
+		time.Sleep(time.Millisecond)
+		m = deliver(m)
+	}
+	return m, nil
+}
+
+func summary(g *game.GameState) string {
+	return fmt.Sprintf("%sscore: %d\n", g.FormatBoard(), g.Score)
+}
+
+func TestMessages_RoundTrip(t *testing.T) {
+	msgs := []message{
+		{Type: msgHello, Version: Version},
+		{Type: msgStart, Seed: -42},
+		{Type: msgEvent, Frame: 123456, Kind: eventInput, Value: 3},
+		{Type: msgFrame, Frame: 7},
+		{Type: msgPing, Nanos: 99},
+		{Type: msgPong, Nanos: 100},
+	}
+
+	var buf bytes.Buffer
+	for _, m := range msgs {
+		if err := writeMessage(&buf, m); err != nil {
+			t.Fatal(err)
+		}
+		// Messages of unknown types are skipped by readers.
+		buf.Write([]byte{200, 0, 2, 1, 2})
+	}
+
+	r := bufio.NewReader(&buf)
+	for _, want := range msgs {
+		got, err := readMessage(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	}
+}
+
+func TestJoin_VersionMismatch(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	go func() {
+		c, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		writeMessage(c, message{Type: msgHello, Version: Version + 1})
+		readMessage(bufio.NewReader(c))
+	}()
+
+	if _, err := Join(l.Addr().String()); !errors.Is(err, ErrVersion) {
+		t.Errorf("Join error = %v, want ErrVersion", err)
+	}
+}
+
+func connect(t *testing.T) (host, join *Conn) {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		var err error
+		host, err = Host(l, 42)
+		done <- err
+	}()
+	join, err = Join(l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		host.Close()
+		join.Close()
+	})
+	return host, join
+}
+
+// actionKeys are the default keys for each action.
+var actionKeys = map[game.Action]tea.KeyMsg{
+	game.ActionLeft:      {Type: tea.KeyLeft},
+	game.ActionRight:     {Type: tea.KeyRight},
+	game.ActionSoftDrop:  {Type: tea.KeyDown},
+	game.ActionHardDrop:  {Type: tea.KeySpace, Runes: []rune{' '}},
+	game.ActionRotateCW:  {Type: tea.KeyUp},
+	game.ActionRotateCCW: {Type: tea.KeyRunes, Runes: []rune{'c'}},
+	game.ActionHold:      {Type: tea.KeyRunes, Runes: []rune{'z'}},
+}
+
+func update(m Match, msg tea.Msg) Match {
+	next, _ := m.Update(msg)
+	return next.(Match)
+}
+
+// deliver hands every message that has arrived to the match.
+func deliver(m Match) Match {
+	for {
+		select {
+		case msg, ok := <-m.conn.incoming:
+			if !ok {
+				return m
+			}
+			m = update(m, netMsg(msg))
+		default:
+			return m
+		}
+	}
+}
+
+// settle delivers messages until each replica has caught up with the other board.
+func settle(t *testing.T, a, b Match) (Match, Match) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for a.oppFrame != b.frame || b.oppFrame != a.frame {
+		if time.Now().After(deadline) {
+			t.Fatalf("replicas did not catch up: %d/%d and %d/%d", a.oppFrame, b.frame, b.oppFrame, a.frame)
+		}
+		time.Sleep(time.Millisecond)
+		a, b = deliver(a), deliver(b)
+	}
+	return a, b
+}
+
+func sameGame(t *testing.T, name string, got, want *game.GameState) {
+	t.Helper()
+	if got.Board != want.Board || got.CurrentPiece.Name != want.CurrentPiece.Name ||
+		got.CurrentX != want.CurrentX || got.CurrentY != want.CurrentY ||
+		got.CurrentRotation != want.CurrentRotation || got.Score != want.Score ||
+		got.PiecesPlaced != want.PiecesPlaced || !slices.Equal(got.PendingGarbage, want.PendingGarbage) {
+		t.Errorf("%s replica diverged:\n%s\nwant\n%s", name, got.FormatBoard(), want.FormatBoard())
+	}
+}
+
+func TestMatch_LoopbackLockstep(t *testing.T) {
+	host, join := connect(t)
+	a, b := NewMatch(host), NewMatch(join)
+	if a.Own.Seed != b.Own.Seed {
+		t.Fatalf("seeds differ: %d and %d", a.Own.Seed, b.Own.Seed)
+	}
+
+	// Give the host a tetris ready on its board and on the joiner's replica of it.
+	setup := `
+current: I
+GGGGGGGGG.
+GGGGGGGGG.
+GGGGGGGGG.
+GGGGGGGGG.
+G.GGGGGGGG`
+	a.Own.LoadBoard(setup)
+	b.Opponent.LoadBoard(setup)
+
+	script := []game.Action{game.ActionRotateCW, game.ActionRight, game.ActionRight, game.ActionRight, game.ActionRight, game.ActionRight, game.ActionHardDrop}
+	for _, action := range script {
+		a = update(a, actionKeys[action])
+		a, b = update(a, tickMsg{}), update(b, tickMsg{})
+		a, b = deliver(a), deliver(b)
+	}
+	a, b = settle(t, a, b)
+
+	if a.Own.LastClear.Lines != 4 {
+		t.Fatalf("host cleared %+v, want a tetris", a.Own.LastClear)
+	}
+	if got := b.Own.PendingLines(); got != 4 {
+		t.Fatalf("joiner has %d pending garbage lines, want 4", got)
+	}
+
+	// Random play on both sides.
+	rng := rand.New(rand.NewSource(1))
+	pool := []game.Action{game.ActionLeft, game.ActionRight, game.ActionSoftDrop, game.ActionHardDrop, game.ActionRotateCW, game.ActionRotateCCW, game.ActionHold}
+	for range 900 {
+		if rng.Intn(3) == 0 {
+			a = update(a, actionKeys[pool[rng.Intn(len(pool))]])
+		}
+		if rng.Intn(3) == 0 {
+			b = update(b, actionKeys[pool[rng.Intn(len(pool))]])
+		}
+		a, b = update(a, tickMsg{}), update(b, tickMsg{})
+		a, b = deliver(a), deliver(b)
+	}
+	a, b = settle(t, a, b)
+
+	if b.Own.PiecesPlaced < 5 {
+		t.Errorf("only %d pieces placed", b.Own.PiecesPlaced)
+	}
+	sameGame(t, "host", &b.Opponent, &a.Own)
+	sameGame(t, "joiner", &a.Opponent, &b.Own)
+}
+
+func TestMatch_Disconnect(t *testing.T) {
+	host, join := connect(t)
+	a := NewMatch(host)
+	join.Close()
+
+	next, _ := a.Update(a.wait()())
+	a = next.(Match)
+	if !a.done || a.status != "Opponent disconnected" {
+		t.Errorf("status %q after the peer closed", a.status)
+	}
+}
+
+func TestConn_CloseStopsReader(t *testing.T) {
+	host, join := connect(t)
+	size := cap(host.incoming)
+	for range size + 100 {
+		if err := join.send(message{Type: msgFrame, Frame: 1}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for len(host.incoming) < size {
+		if time.Now().After(deadline) {
+			t.Fatalf("only %d of %d messages arrived", len(host.incoming), size)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// The reader is blocked on a full channel that nobody reads.
+	host.Close()
+	got := 0
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-host.incoming:
+			if !ok {
+				if got > size+1 {
+					t.Errorf("received %d messages after Close, want at most %d", got, size+1)
+				}
+				return
+			}
+			got++
+		case <-timeout:
+			t.Fatal("incoming was not closed")
+		}
+	}
+}
+
+func TestMatch_TwoProcesses(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	l.(*net.TCPListener).SetDeadline(time.Now().Add(10 * time.Second))
+
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	cmd := exec.Command(exe)
+	cmd.Env = append(os.Environ(), "NETPLAY_PEER="+l.Addr().String())
+	cmd.Stdout = &out
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Host(l, 42)
+	if err != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+		t.Fatalf("Host: %v", err)
+	}
+	m, err := playScript(NewMatch(c), hostScript)
+	c.Close()
+	if waitErr := cmd.Wait(); waitErr != nil {
+		t.Fatalf("peer process: %v", waitErr)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	own, opponent, ok := strings.Cut(out.String(), "--\n")
+	if !ok {
+		t.Fatalf("unexpected peer output:\n%s", out.String())
+	}
+	if m.Own.PiecesPlaced == 0 || m.Opponent.PiecesPlaced == 0 {
+		t.Fatalf("pieces placed: host %d, peer %d", m.Own.PiecesPlaced, m.Opponent.PiecesPlaced)
+	}
+	if got := summary(&m.Opponent); got != own {
+		t.Errorf("host's replica of the peer:\n%s\nwant\n%s", got, own)
+	}
+	if got := summary(&m.Own); got != opponent {
+		t.Errorf("peer's replica of the host:\n%s\nwant\n%s", opponent, got)
+	}
+}
@@ -0,0 +1,157 @@
+// Package netplay runs 1v1 matches over TCP. Each client simulates its own
+// board and streams frame-stamped inputs and garbage events to the other,
+// which replays them in lockstep on a copy of the opponent's board. Because
+// the engine is deterministic, both clients see the same two games.
+package netplay
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"termino/internal/game"
+)
+
+// Version is the protocol version. Clients refuse peers with another version.
+const Version = 1
+
+// magic opens every connection.
+var magic = [4]byte{'T', 'R', 'M', 'N'}
+
+// ErrVersion is returned when the peer speaks another protocol version.
+var ErrVersion = errors.New("netplay: protocol version mismatch")
+
+// Message types. Each message is a type byte and a 16-bit payload length
+// followed by the payload, so that unknown types can be skipped.
+const (
+	msgHello uint8 = iota + 1 // Magic and version
+	msgStart                  // Seed chosen by the host
+	msgEvent                  // An input or incoming garbage on the sender's board
+	msgFrame                  // The sender has simulated frames before Frame
+	msgPing                   // Sender's clock, echoed back in a pong
+	msgPong
+)
+
+// Event kinds.
+const (
+	eventInput   uint8 = iota + 1 // Value is an index into actions
+	eventGarbage                  // Value is the number of lines received
+)
+
+// actions numbers the gameplay actions on the wire.
+var actions = []game.Action{
+	game.ActionLeft,
+	game.ActionRight,
+	game.ActionSoftDrop,
+	game.ActionHardDrop,
+	game.ActionRotateCW,
+	game.ActionRotateCCW,
+	game.ActionRotate180,
+	game.ActionHold,
+	game.ActionDASLeft,
+	game.ActionDASRight,
+}
+
+// message is any protocol message; only the fields of its type are used.
+type message struct {
+	Type    uint8
+	Version uint16
+	Seed    int64
+	Frame   uint32
+	Kind    uint8
+	Value   uint8
+	Nanos   int64
+}
+
+func writeMessage(w io.Writer, m message) error {
+	var payload []byte
+	switch m.Type {
+	case msgHello:
+		payload = binary.BigEndian.AppendUint16(magic[:], m.Version)
+	case msgStart:
+		payload = binary.BigEndian.AppendUint64(nil, uint64(m.Seed))
+	case msgEvent:
+		payload = binary.BigEndian.AppendUint32(nil, m.Frame)
+		payload = append(payload, m.Kind, m.Value)
+	case msgFrame:
+		payload = binary.BigEndian.AppendUint32(nil, m.Frame)
+	case msgPing, msgPong:
+		payload = binary.BigEndian.AppendUint64(nil, uint64(m.Nanos))
+	default:
+		return fmt.Errorf("netplay: unknown message type %d", m.Type)
+	}
+
+	buf := make([]byte, 0, 3+len(payload))
+	buf = append(buf, m.Type)
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(payload)))
+	buf = append(buf, payload...)
+	_, err := w.Write(buf)
+	return err
+}
+
+// readMessage reads the next message of a known type, skipping others.
+func readMessage(r *bufio.Reader) (message, error) {
+	for {
+		var header [3]byte
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			return message{}, err
+		}
+		payload := make([]byte, binary.BigEndian.Uint16(header[1:]))
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return message{}, err
+		}
+
+		m := message{Type: header[0]}
+		short := func(n int) error {
+			if len(payload) < n {
+				return fmt.Errorf("netplay: message type %d too short", m.Type)
+			}
+			return nil
+		}
+		switch m.Type {
+		case msgHello:
+			if err := short(6); err != nil {
+				return m, err
+			}
+			if [4]byte(payload[:4]) != magic {
+				return m, errors.New("netplay: peer is not a termino client")
+			}
+			m.Version = binary.BigEndian.Uint16(payload[4:])
+		case msgStart:
+			if err := short(8); err != nil {
+				return m, err
+			}
+			m.Seed = int64(binary.BigEndian.Uint64(payload))
+		case msgEvent:
+			if err := short(6); err != nil {
+				return m, err
+			}
+			m.Frame = binary.BigEndian.Uint32(payload)
+			m.Kind, m.Value = payload[4], payload[5]
+		case msgFrame:
+			if err := short(4); err != nil {
+				return m, err
+			}
+			m.Frame = binary.BigEndian.Uint32(payload)
+		case msgPing, msgPong:
+			if err := short(8); err != nil {
+				return m, err
+			}
+			m.Nanos = int64(binary.BigEndian.Uint64(payload))
+		default:
+			continue
+		}
+		return m, nil
+	}
+}
+
+func actionIndex(a game.Action) (uint8, bool) {
+	for i, b := range actions {
+		if a == b {
+			return uint8(i), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,172 @@
+package raster
+
+import (
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"strconv"
+
+	"termino/internal/game"
+	"termino/internal/tetromino"
+	"termino/pkg/consts"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	CellSize     = 16 // Board cell size in pixels
+	MiniCellSize = 8  // Hold and next queue cell size in pixels
+	Margin       = 8
+
+	panelWidth = 4*MiniCellSize + 2*Margin
+	boardW     = consts.BoardWidth * CellSize
+	boardH     = consts.VisibleHeight * CellSize
+)
+
+var (
+	colorBackground = color.RGBA{0x10, 0x10, 0x10, 0xFF}
+	colorWell       = color.RGBA{0x00, 0x00, 0x00, 0xFF}
+	colorGrid       = color.RGBA{0x1C, 0x1C, 0x1C, 0xFF}
+	colorBorder     = color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}
+	colorGhost      = color.RGBA{0x66, 0x66, 0x66, 0xFF}
+	colorGarbage    = color.RGBA{0x88, 0x88, 0x88, 0xFF}
+)
+
+// Palette holds every colour the rasterizer draws with, so frames can be
+// encoded into a GIF without quantization.
+var Palette = color.Palette{
+	colorBackground,
+	colorWell,
+	colorGrid,
+	colorBorder,
+	colorGhost,
+	colorGarbage,
+	parseColor(tetromino.ColorI),
+	parseColor(tetromino.ColorJ),
+	parseColor(tetromino.ColorL),
+	parseColor(tetromino.ColorO),
+	parseColor(tetromino.ColorS),
+	parseColor(tetromino.ColorT),
+	parseColor(tetromino.ColorZ),
+}
+
+// Bounds returns the size of every rendered frame.
+func Bounds() image.Rectangle {
+	return image.Rect(0, 0, 2*panelWidth+boardW+2, boardH+2+2*Margin)
+}
+
+// Frame draws the board, ghost, active piece, hold and next queue of g.
+func Frame(g *game.GameState) *image.Paletted {
+	img := image.NewPaletted(Bounds(), Palette)
+	fill(img, img.Bounds(), colorBackground)
+
+	boardX := panelWidth + 1
+	boardY := Margin + 1
+	fill(img, image.Rect(boardX-1, boardY-1, boardX+boardW+1, boardY+boardH+1), colorBorder)
+	fill(img, image.Rect(boardX, boardY, boardX+boardW, boardY+boardH), colorWell)
+
+	visibleStart := consts.BoardHeight - consts.VisibleHeight
+	for y := range consts.VisibleHeight {
+		row := visibleStart + y
+		for x := range consts.BoardWidth {
+			var c color.Color = colorGrid
+			if g.Board[row]&tetromino.Bitmask(1<<x) != 0 {
+				c = blockColor(g.BoardColors[row][x])
+			}
+			drawCell(img, boardX+x*CellSize, boardY+y*CellSize, CellSize, c)
+		}
+	}
+
+	if !g.GameOver {
+		drawPiece(img, g.CurrentPiece, g.CurrentRotation, g.CurrentX, g.GhostY-visibleStart, boardX, boardY, CellSize, colorGhost)
+		drawPiece(img, g.CurrentPiece, g.CurrentRotation, g.CurrentX, g.CurrentY-visibleStart, boardX, boardY, CellSize, parseColor(g.CurrentPiece.Color))
+	}
+
+	if g.HoldPiece != nil {
+		drawPiece(img, *g.HoldPiece, 0, 0, 0, Margin, Margin, MiniCellSize, parseColor(g.HoldPiece.Color))
+	}
+	nextX := boardX + boardW + 1 + Margin
+	for i, piece := range g.NextQueue {
+		drawPiece(img, piece, 0, 0, i*3, nextX, Margin, MiniCellSize, parseColor(piece.Color))
+	}
+
+	return img
+}
+
+// WritePNG encodes a single frame of g as a PNG image.
+func WritePNG(w io.Writer, g *game.GameState) error {
+	return png.Encode(w, Frame(g))
+}
+
+// Animation accumulates frames for an animated GIF.
+type Animation struct {
+	gif gif.GIF
+}
+
+// Add appends a frame of g shown for delay hundredths of a second.
+func (a *Animation) Add(g *game.GameState, delay int) {
+	a.gif.Image = append(a.gif.Image, Frame(g))
+	a.gif.Delay = append(a.gif.Delay, delay)
+}
+
+// Len returns the number of frames added so far.
+func (a *Animation) Len() int { return len(a.gif.Image) }
+
+// Encode writes the animation as a looping GIF.
+func (a *Animation) Encode(w io.Writer) error {
+	return gif.EncodeAll(w, &a.gif)
+}
+
+// drawPiece draws piece in rotation rot with its 4x4 mask origin at board cell (px, py).
+// Rows above the top of the image are skipped.
+func drawPiece(img *image.Paletted, piece tetromino.Tetromino, rot, px, py, offX, offY, size int, c color.Color) {
+	mask := piece.Masks[rot]
+	for r := range 4 {
+		if py+r < 0 {
+			continue
+		}
+		for col := range 4 {
+			if mask[r]&tetromino.Bitmask(1<<col) != 0 {
+				drawCell(img, offX+(px+col)*size, offY+(py+r)*size, size, c)
+			}
+		}
+	}
+}
+
+// drawCell fills a cell leaving a one pixel gap so adjacent blocks stay distinguishable.
+func drawCell(img *image.Paletted, x, y, size int, c color.Color) {
+	fill(img, image.Rect(x, y, x+size-1, y+size-1), c)
+}
+
+func fill(img *image.Paletted, r image.Rectangle, c color.Color) {
+	idx := uint8(Palette.Index(c))
+	r = r.Intersect(img.Bounds())
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			img.SetColorIndex(x, y, idx)
+		}
+	}
+}
+
+// blockColor returns the colour of a locked cell, treating unset colours as garbage.
+func blockColor(c lipgloss.Color) color.RGBA {
+	if c == "" {
+		return colorGarbage
+	}
+	return parseColor(c)
+}
+
+// parseColor converts a "#RRGGBB" lipgloss colour to RGBA. Anything else is drawn as garbage.
+func parseColor(c lipgloss.Color) color.RGBA {
+	s := string(c)
+	if len(s) != 7 || s[0] != '#' {
+		return colorGarbage
+	}
+	v, err := strconv.ParseUint(s[1:], 16, 32)
+	if err != nil {
+		return colorGarbage
+	}
+	return color.RGBA{uint8(v >> 16), uint8(v >> 8), uint8(v), 0xFF}
+}
@@ -0,0 +1,134 @@
+package raster
+
+import (
+	"bytes"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"testing"
+
+	"termino/internal/game"
+	"termino/internal/tetromino"
+	"termino/pkg/consts"
+)
+
+// cellOrigin returns the top-left pixel of the visible board cell (x, y).
+func cellOrigin(x, y int) (int, int) {
+	return panelWidth + 1 + x*CellSize, Margin + 1 + y*CellSize
+}
+
+func testState() game.GameState {
+	g := game.NewGameStateWithSeed(1)
+	bottom := consts.BoardHeight - 1
+	g.Board[bottom] |= 1<<0 | 1<<1
+	g.BoardColors[bottom][0] = tetromino.ColorT
+	g.BoardColors[bottom][1] = "" // Garbage
+	g.UpdateGhost()
+	return g
+}
+
+func TestFrame_Pixels(t *testing.T) {
+	g := testState()
+	img := Frame(&g)
+
+	if img.Bounds() != Bounds() {
+		t.Fatalf("Expected bounds %v, got %v", Bounds(), img.Bounds())
+	}
+	if len(img.Palette) != len(Palette) {
+		t.Fatalf("Expected %d palette colours, got %d", len(Palette), len(img.Palette))
+	}
+
+	bottom := consts.VisibleHeight - 1
+	x, y := cellOrigin(0, bottom)
+	gx, gy := cellOrigin(1, bottom)
+	ex, ey := cellOrigin(consts.BoardWidth-1, bottom)
+	tests := []struct {
+		name string
+		x, y int
+		want color.Color
+	}{
+		{"background", 0, 0, colorBackground},
+		{"border", x - 1, y, colorBorder},
+		{"locked cell", x, y, parseColor(tetromino.ColorT)},
+		{"cell gap", x + CellSize - 1, y, colorWell},
+		{"garbage cell", gx, gy, colorGarbage},
+		{"empty cell", ex, ey, colorGrid},
+	}
+	for _, tt := range tests {
+		if got := img.At(tt.x, tt.y); got != tt.want {
+			t.Errorf("%s at (%d, %d): expected %v, got %v", tt.name, tt.x, tt.y, tt.want, got)
+		}
+	}
+
+	mask := g.CurrentPiece.Masks[g.CurrentRotation]
+	for r := range 4 {
+		for c := range 4 {
+			if mask[r]&tetromino.Bitmask(1<<c) == 0 {
+				continue
+			}
+			visible := consts.BoardHeight - consts.VisibleHeight
+			px, py := cellOrigin(g.CurrentX+c, g.GhostY-visible+r)
+			if got := img.At(px, py); got != colorGhost {
+				t.Errorf("Expected ghost at cell (%d, %d), got %v", g.CurrentX+c, g.GhostY-visible+r, got)
+			}
+		}
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+	g := testState()
+	var buf bytes.Buffer
+	if err := WritePNG(&buf, &g); err != nil {
+		t.Fatalf("WritePNG failed: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if img.Bounds() != Bounds() {
+		t.Errorf("Expected bounds %v, got %v", Bounds(), img.Bounds())
+	}
+}
+
+func TestAnimation_Encode(t *testing.T) {
+	g := testState()
+	var a Animation
+	delays := []int{5, 5, 100}
+	for _, d := range delays {
+		a.Add(&g, d)
+		g.Apply(game.ActionHardDrop)
+	}
+	if a.Len() != len(delays) {
+		t.Fatalf("Expected %d frames, got %d", len(delays), a.Len())
+	}
+
+	var buf bytes.Buffer
+	if err := a.Encode(&buf); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	decoded, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatalf("DecodeAll failed: %v", err)
+	}
+	if len(decoded.Image) != len(delays) {
+		t.Fatalf("Expected %d decoded frames, got %d", len(delays), len(decoded.Image))
+	}
+	for i, d := range delays {
+		if decoded.Delay[i] != d {
+			t.Errorf("Frame %d: expected delay %d, got %d", i, d, decoded.Delay[i])
+		}
+	}
+	// The encoder pads the palette to a power of two.
+	pal := decoded.Image[0].Palette
+	for i, c := range Palette {
+		if i >= len(pal) || !sameColor(pal[i], c) {
+			t.Fatalf("GIF palette differs at %d: expected %v, got %v", i, c, pal)
+		}
+	}
+}
+
+func sameColor(a, b color.Color) bool {
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+	return ar == br && ag == bg && ab == bb && aa == ba
+}
@@ -6,18 +6,21 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
+// Buffer is a grid of styled cells drawn a frame at a time. Render returns the
+// whole frame; RenderDiff only what changed since the last call.
 type Buffer struct {
 	width, height int
 	current       [][]rune
-	previous      [][]rune
-	styles        [][]lipgloss.Style
+	previous      [][]rune   // Frame last returned by RenderDiff
+	styles        [][]string // SGR parameters of each cell, "" for the plain style
+	prevStyles    [][]string
 }
 
 func (b *Buffer) Width() int  { return b.width }
 func (b *Buffer) Height() int { return b.height }
 
 func (b *Buffer) DimArea(x, y, w, h int) {
-	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#444444"))
+	dimStyle := sgr(lipgloss.NewStyle().Foreground(lipgloss.Color("#444444")))
 	for r := y; r < y+h; r++ {
 		for c := x; c < x+w; c++ {
 			if r >= 0 && r < b.height && c >= 0 && c < b.width {
@@ -36,17 +39,19 @@ func (b *Buffer) DimArea(x, y, w, h int) {
 
 func NewBuffer(width, height int) *Buffer {
 	b := &Buffer{
-		width:    width,
-		height:   height,
-		current:  make([][]rune, height),
-		previous: make([][]rune, height),
-		styles:   make([][]lipgloss.Style, height),
+		width:      width,
+		height:     height,
+		current:    make([][]rune, height),
+		previous:   make([][]rune, height),
+		styles:     make([][]string, height),
+		prevStyles: make([][]string, height),
 	}
 
 	for i := range b.current {
 		b.current[i] = make([]rune, width)
 		b.previous[i] = make([]rune, width)
-		b.styles[i] = make([]lipgloss.Style, width)
+		b.styles[i] = make([]string, width)
+		b.prevStyles[i] = make([]string, width)
 
 		for j := range b.current[i] {
 			b.current[i][j] = ' '
@@ -56,22 +61,32 @@ func NewBuffer(width, height int) *Buffer {
 	return b
 }
 
+// Resize changes the size of b in place and clears it.
+func (b *Buffer) Resize(width, height int) {
+	*b = *NewBuffer(width, height)
+}
+
 func (b *Buffer) Set(x, y int, char rune, style lipgloss.Style) {
 	if x < 0 || x >= b.width || y < 0 || y >= b.height {
 		return
 	}
 	b.current[y][x] = char
-	b.styles[y][x] = style
+	b.styles[y][x] = sgr(style)
 }
 
+// Render returns the whole frame, one line per row. Runs of cells in the same
+// style share a single SGR sequence.
 func (b *Buffer) Render() string {
 	var sb strings.Builder
+	sb.Grow(b.width * b.height * 2)
 
 	for y := 0; y < b.height; y++ {
+		pen := ""
 		for x := 0; x < b.width; x++ {
-			// Render the cell
-			sb.WriteString(b.styles[y][x].Render(string(b.current[y][x])))
+			pen = switchStyle(&sb, pen, b.styles[y][x])
+			sb.WriteRune(b.current[y][x])
 		}
+		switchStyle(&sb, pen, "")
 		if y < b.height-1 {
 			sb.WriteString("\n")
 		}
@@ -84,7 +99,7 @@ func (b *Buffer) Reset() {
 	for y := 0; y < b.height; y++ {
 		for x := 0; x < b.width; x++ {
 			b.current[y][x] = ' '
-			b.styles[y][x] = lipgloss.NewStyle()
+			b.styles[y][x] = ""
 		}
 	}
 }
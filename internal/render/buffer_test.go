@@ -0,0 +1,180 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+)
+
+// useProfile draws with profile for the rest of the test.
+func useProfile(tb testing.TB, profile termenv.Profile) {
+	old := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(profile)
+	tb.Cleanup(func() { lipgloss.SetColorProfile(old) })
+}
+
+var (
+	red  = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000"))
+	bold = lipgloss.NewStyle().Bold(true)
+)
+
+func write(b *Buffer, x, y int, text string, style lipgloss.Style) {
+	for i, r := range text {
+		b.Set(x+i, y, r, style)
+	}
+}
+
+func TestRender_GroupsRuns(t *testing.T) {
+	useProfile(t, termenv.TrueColor)
+	b := NewBuffer(6, 2)
+	write(b, 0, 0, "ab", red)
+	write(b, 2, 0, "c", bold)
+	write(b, 1, 1, "d", red)
+
+	want := "\x1b[38;2;255;0;0mab\x1b[0;1mc\x1b[0m   \n \x1b[38;2;255;0;0md\x1b[0m    "
+	if got := b.Render(); got != want {
+		t.Errorf("Render = %q, want %q", got, want)
+	}
+}
+
+func TestRender_PlainWithoutColours(t *testing.T) {
+	useProfile(t, termenv.Ascii)
+	b := NewBuffer(3, 1)
+	write(b, 0, 0, "abc", red)
+	if got := b.Render(); got != "abc" {
+		t.Errorf("Render = %q, want abc", got)
+	}
+}
+
+func TestRenderDiff(t *testing.T) {
+	useProfile(t, termenv.TrueColor)
+	b := NewBuffer(20, 3)
+	if got := b.RenderDiff(); got != "" {
+		t.Errorf("RenderDiff of a blank frame = %q, want nothing", got)
+	}
+
+	b.Set(2, 1, 'x', lipgloss.NewStyle())
+	b.Set(4, 1, 'y', red)
+	b.Set(15, 2, 'z', lipgloss.NewStyle())
+	// The cell between x and y is rewritten rather than skipped with a move.
+	want := "\x1b[2;3Hx \x1b[38;2;255;0;0my\x1b[3;16H\x1b[0mz"
+	if got := b.RenderDiff(); got != want {
+		t.Errorf("RenderDiff = %q, want %q", got, want)
+	}
+
+	b.Reset()
+	b.Set(2, 1, 'x', lipgloss.NewStyle())
+	b.Set(4, 1, 'y', bold)
+	b.Set(15, 2, 'z', lipgloss.NewStyle())
+	want = "\x1b[2;5H\x1b[1my\x1b[0m"
+	if got := b.RenderDiff(); got != want {
+		t.Errorf("RenderDiff after a style change = %q, want %q", got, want)
+	}
+	if got := b.RenderDiff(); got != "" {
+		t.Errorf("RenderDiff of an unchanged frame = %q, want nothing", got)
+	}
+
+	b.Invalidate()
+	if got := ansi.Strip(b.RenderDiff()); strings.Count(got, " ") != 20*3-3 {
+		t.Errorf("RenderDiff after Invalidate = %q, want every cell", got)
+	}
+}
+
+// drawFrame draws a screen like a game in progress, with the falling piece
+// a row lower every frame.
+func drawFrame(set func(x, y int, r rune, style lipgloss.Style), frame int) {
+	white := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	colours := []string{"#00FFFF", "#FFFF00", "#800080", "#00FF00", "#FF0000", "#0000FF", "#FFA500"}
+
+	for y := 1; y <= 22; y++ {
+		set(29, y, '│', white)
+		set(50, y, '│', white)
+	}
+	for x := 30; x < 50; x++ {
+		set(x, 0, '─', white)
+		set(x, 23, '─', white)
+	}
+	for y := 12; y <= 22; y++ {
+		for col := range 10 {
+			if col == (y*3)%10 {
+				continue
+			}
+			style := lipgloss.NewStyle().Foreground(lipgloss.Color(colours[(y+col)%len(colours)]))
+			set(30+col*2, y, '█', style)
+			set(31+col*2, y, '█', style)
+		}
+	}
+	piece := lipgloss.NewStyle().Foreground(lipgloss.Color(colours[frame/10%len(colours)]))
+	for i := range 4 {
+		set(36+i*2, 1+frame%10, '█', piece)
+		set(37+i*2, 1+frame%10, '█', piece)
+	}
+	for i, r := range fmt.Sprintf("Score: %6d", frame*100) {
+		set(18+i, 9, r, white)
+	}
+}
+
+// BenchmarkRender compares, per frame, rendering every cell through lipgloss
+// as Render used to, Render's runs of styled cells, and RenderDiff.
+func BenchmarkRender(b *testing.B) {
+	useProfile(b, termenv.TrueColor)
+	const w, h = 80, 24
+
+	b.Run("lipgloss", func(b *testing.B) {
+		b.ReportAllocs()
+		runes := make([][]rune, h)
+		styles := make([][]lipgloss.Style, h)
+		for y := range h {
+			runes[y] = make([]rune, w)
+			styles[y] = make([]lipgloss.Style, w)
+		}
+		bytes := 0
+		for i := 0; i < b.N; i++ {
+			for y := range h {
+				for x := range w {
+					runes[y][x], styles[y][x] = ' ', lipgloss.NewStyle()
+				}
+			}
+			drawFrame(func(x, y int, r rune, style lipgloss.Style) {
+				runes[y][x], styles[y][x] = r, style
+			}, i)
+			var sb strings.Builder
+			for y := range h {
+				for x := range w {
+					sb.WriteString(styles[y][x].Render(string(runes[y][x])))
+				}
+				sb.WriteString("\n")
+			}
+			bytes += sb.Len()
+		}
+		b.ReportMetric(float64(bytes)/float64(b.N), "bytes/frame")
+	})
+
+	b.Run("runs", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := NewBuffer(w, h)
+		bytes := 0
+		for i := 0; i < b.N; i++ {
+			buf.Reset()
+			drawFrame(buf.Set, i)
+			bytes += len(buf.Render())
+		}
+		b.ReportMetric(float64(bytes)/float64(b.N), "bytes/frame")
+	})
+
+	b.Run("diff", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := NewBuffer(w, h)
+		bytes := 0
+		for i := 0; i < b.N; i++ {
+			buf.Reset()
+			drawFrame(buf.Set, i)
+			bytes += len(buf.RenderDiff())
+		}
+		b.ReportMetric(float64(bytes)/float64(b.N), "bytes/frame")
+	})
+}
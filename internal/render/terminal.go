@@ -1,4 +1,176 @@
 package render
 
-// This file is intentionally empty to replace the deleted terminal.go
-// and ensure no stale references cause import cycles.
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Escape sequences written between cells.
+const (
+	csi      = "\x1b["
+	resetSGR = csi + "0m"
+)
+
+// maxSkip is the longest run of unchanged cells RenderDiff rewrites rather
+// than moving the cursor over, since a cursor move takes about as many bytes.
+const maxSkip = 4
+
+// styleKey holds the parts of a lipgloss style that affect a single cell.
+type styleKey struct {
+	fg, bg  lipgloss.TerminalColor
+	attrs   uint8 // Bit i set for attribute i of cellAttrs
+	profile termenv.Profile
+}
+
+// cellAttrs are the text attributes a cell can have, in the order lipgloss
+// writes them.
+var cellAttrs = []struct {
+	get  func(lipgloss.Style) bool
+	code string
+}{
+	{lipgloss.Style.GetBold, termenv.BoldSeq},
+	{lipgloss.Style.GetItalic, termenv.ItalicSeq},
+	{lipgloss.Style.GetUnderline, termenv.UnderlineSeq},
+	{lipgloss.Style.GetReverse, termenv.ReverseSeq},
+	{lipgloss.Style.GetBlink, termenv.BlinkSeq},
+	{lipgloss.Style.GetFaint, termenv.FaintSeq},
+	{lipgloss.Style.GetStrikethrough, termenv.CrossOutSeq},
+}
+
+// sgrCache maps style keys to their SGR parameters. Buffers are drawn into
+// with the same few styles every frame, so each is converted only once.
+var (
+	sgrMu    sync.RWMutex
+	sgrCache = map[styleKey]string{}
+)
+
+// sgr returns the SGR parameters that draw a cell in style with the default
+// renderer's colour profile, as lipgloss would, or "" for the plain style.
+func sgr(style lipgloss.Style) string {
+	profile := lipgloss.ColorProfile()
+	if profile == termenv.Ascii {
+		return ""
+	}
+
+	key := styleKey{fg: style.GetForeground(), bg: style.GetBackground(), profile: profile}
+	for i, a := range cellAttrs {
+		if a.get(style) {
+			key.attrs |= 1 << i
+		}
+	}
+	sgrMu.RLock()
+	params, ok := sgrCache[key]
+	sgrMu.RUnlock()
+	if ok {
+		return params
+	}
+
+	var parts []string
+	for i, a := range cellAttrs {
+		if key.attrs&(1<<i) != 0 {
+			parts = append(parts, a.code)
+		}
+	}
+	if c := termColor(profile, key.fg); c != nil {
+		parts = append(parts, c.Sequence(false))
+	}
+	if c := termColor(profile, key.bg); c != nil {
+		parts = append(parts, c.Sequence(true))
+	}
+	params = strings.Join(parts, ";")
+	sgrMu.Lock()
+	sgrCache[key] = params
+	sgrMu.Unlock()
+	return params
+}
+
+// termColor converts a lipgloss colour for profile. It returns nil for no
+// colour.
+func termColor(profile termenv.Profile, c lipgloss.TerminalColor) termenv.Color {
+	var s string
+	switch c := c.(type) {
+	case nil, lipgloss.NoColor:
+		return nil
+	case lipgloss.Color:
+		s = string(c)
+	default:
+		r, g, b, _ := c.RGBA()
+		s = fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+	}
+	tc := profile.Color(s)
+	if _, ok := tc.(termenv.NoColor); ok {
+		return nil
+	}
+	return tc
+}
+
+// switchStyle writes the sequence that changes the style of the text that
+// follows from pen to style, and returns the new pen.
+func switchStyle(sb *strings.Builder, pen, style string) string {
+	switch {
+	case style == pen:
+	case style == "":
+		sb.WriteString(resetSGR)
+	case pen == "":
+		sb.WriteString(csi + style + "m")
+	default:
+		sb.WriteString(csi + "0;" + style + "m")
+	}
+	return style
+}
+
+// RenderDiff returns the output that brings a terminal showing the previous
+// frame up to date with the current one: a cursor move to each run of changed
+// cells followed by their text, switching styles only where they change. The
+// current frame then becomes the previous one. Rows and columns count from
+// the top-left corner of the terminal.
+func (b *Buffer) RenderDiff() string {
+	var sb strings.Builder
+	pen := ""
+	cx, cy := -1, -1 // Cursor position, unknown until the first move
+	for y := range b.height {
+		for x := range b.width {
+			if !b.changed(x, y) {
+				continue
+			}
+			if y == cy && x >= cx && x-cx <= maxSkip {
+				// Rewriting the few cells in between is shorter than a move.
+				for ; cx < x; cx++ {
+					pen = switchStyle(&sb, pen, b.styles[y][cx])
+					sb.WriteRune(b.current[y][cx])
+				}
+			} else {
+				sb.WriteString(csi + strconv.Itoa(y+1) + ";" + strconv.Itoa(x+1) + "H")
+			}
+			pen = switchStyle(&sb, pen, b.styles[y][x])
+			sb.WriteRune(b.current[y][x])
+			cx, cy = x+1, y
+		}
+	}
+	switchStyle(&sb, pen, "")
+
+	for y := range b.height {
+		copy(b.previous[y], b.current[y])
+		copy(b.prevStyles[y], b.styles[y])
+	}
+	return sb.String()
+}
+
+// Invalidate makes the next RenderDiff redraw every cell, e.g. after the
+// terminal was cleared.
+func (b *Buffer) Invalidate() {
+	for y := range b.height {
+		for x := range b.width {
+			b.previous[y][x] = 0
+		}
+	}
+}
+
+func (b *Buffer) changed(x, y int) bool {
+	return b.current[y][x] != b.previous[y][x] || b.styles[y][x] != b.prevStyles[y][x]
+}
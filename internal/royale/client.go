@@ -0,0 +1,95 @@
+package royale
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"termino/internal/game"
+)
+
+// Client is a connection to a royale server.
+type Client struct {
+	ID int // Index of our player in the match
+
+	conn     net.Conn
+	enc      *json.Encoder
+	mu       sync.Mutex // Serialises writes
+	incoming chan message
+	err      error // Why incoming was closed
+}
+
+// Dial joins the lobby of the server at addr under name.
+func Dial(addr, name string) (*Client, error) {
+	conn, err := net.DialTimeout("tcp", addr, helloTimeout)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{conn: conn, enc: json.NewEncoder(conn), incoming: make(chan message, 64)}
+	dec := json.NewDecoder(bufio.NewReader(conn))
+
+	conn.SetDeadline(time.Now().Add(helloTimeout))
+	if err := c.send(message{Type: msgHello, Version: Version, Name: name}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	var welcome message
+	if err := dec.Decode(&welcome); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	switch welcome.Type {
+	case msgWelcome:
+	case msgError:
+		conn.Close()
+		if welcome.Version != 0 && welcome.Version != Version {
+			return nil, fmt.Errorf("%w: server has %d, we have %d", ErrVersion, welcome.Version, Version)
+		}
+		return nil, fmt.Errorf("royale: %s", welcome.Error)
+	default:
+		conn.Close()
+		return nil, fmt.Errorf("royale: expected welcome, got %q", welcome.Type)
+	}
+	conn.SetDeadline(time.Time{})
+
+	c.ID = welcome.ID
+	c.incoming <- welcome
+	go c.readLoop(dec)
+	return c, nil
+}
+
+func (c *Client) readLoop(dec *json.Decoder) {
+	for {
+		var msg message
+		if err := dec.Decode(&msg); err != nil {
+			c.err = err
+			close(c.incoming)
+			return
+		}
+		c.incoming <- msg
+	}
+}
+
+func (c *Client) send(msg message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.enc.Encode(msg)
+}
+
+// Input sends a gameplay action for our player.
+func (c *Client) Input(a game.Action) error {
+	return c.send(message{Type: msgInput, Action: a})
+}
+
+// Target changes our targeting strategy.
+func (c *Client) Target(s Strategy) error {
+	return c.send(message{Type: msgTarget, Strategy: s.String()})
+}
+
+// Close leaves the match.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,179 @@
+// Package royale runs battle-royale matches: any number of players, humans
+// over TCP or bots, send garbage to each other according to a targeting
+// strategy until one player is left.
+package royale
+
+import (
+	"math/rand"
+
+	"termino/internal/game"
+	"termino/pkg/consts"
+)
+
+// retargetFrames is how long a random target is kept before another is drawn.
+const retargetFrames = 3 * consts.TickRate
+
+// badgeTiers are the badge points needed for each step of attack bonus.
+// Every tier adds a quarter of the attack.
+var badgeTiers = [...]int{2, 6, 14, 30}
+
+// Player is one board in a match.
+type Player struct {
+	Name     string
+	State    game.GameState
+	Strategy Strategy
+	Target   int // Player attacked next, -1 when there is nobody to attack
+	KOs      int
+	Badges   int // Badge points: one per KO plus the badges of the players knocked out
+	Place    int // Finishing place, 0 while still playing
+	Bot      *game.Autopilot
+
+	lastAttacker int // Player whose garbage arrived last, credited with the KO
+	retarget     int // Frames left before a random target is redrawn
+}
+
+// Alive reports whether the player is still in the match.
+func (p *Player) Alive() bool {
+	return p.Place == 0
+}
+
+// BadgeTier returns how many badge tiers the player has reached.
+func (p *Player) BadgeTier() int {
+	tier := 0
+	for _, need := range badgeTiers {
+		if p.Badges >= need {
+			tier++
+		}
+	}
+	return tier
+}
+
+// Match is a battle royale. All players get the same piece sequence.
+type Match struct {
+	Players []*Player
+	Frame   int
+	Over    bool
+	Winner  int // Index of the last player standing, -1 while playing or if nobody is
+
+	seed int64
+	rng  *rand.Rand
+}
+
+// NewMatch creates a match whose pieces and random targets come from seed.
+func NewMatch(seed int64) *Match {
+	return &Match{seed: seed, rng: rand.New(rand.NewSource(seed)), Winner: -1}
+}
+
+// Join adds a player and returns its index. Bots are controlled by pilot;
+// humans pass nil and send their inputs with Apply.
+func (m *Match) Join(name string, pilot *game.Pilot) int {
+	p := &Player{
+		Name:         name,
+		State:        game.NewGameStateWithSeed(m.seed),
+		Target:       -1,
+		lastAttacker: -1,
+	}
+	if pilot != nil {
+		p.Bot = &game.Autopilot{Pilot: *pilot}
+	}
+	m.Players = append(m.Players, p)
+	return len(m.Players) - 1
+}
+
+// Apply performs a player's input.
+func (m *Match) Apply(i int, a game.Action) {
+	p := m.Players[i]
+	if m.Over || !p.Alive() {
+		return
+	}
+	p.State.Apply(a)
+}
+
+// Eliminate takes a player out of the match, as when they disconnect.
+func (m *Match) Eliminate(i int) {
+	m.Players[i].State.GameOver = true
+}
+
+// Step advances the match by one frame: bots play, gravity applies, attacks
+// are routed and players who topped out are eliminated.
+func (m *Match) Step() {
+	if m.Over {
+		return
+	}
+	for _, p := range m.Players {
+		if !p.Alive() || p.State.GameOver {
+			continue
+		}
+		if p.Bot != nil {
+			p.Bot.Step(&p.State)
+		}
+		p.State.ApplyGravity(1.0 / consts.TickRate)
+	}
+	m.Frame++
+
+	m.chooseTargets()
+	m.routeAttacks()
+	m.eliminate()
+}
+
+// routeAttacks sends every player's attack, raised by their badges, to the
+// players they target.
+func (m *Match) routeAttacks() {
+	for i, p := range m.Players {
+		attack := p.State.Outgoing
+		p.State.Outgoing = 0
+		if attack == 0 || !p.Alive() {
+			continue
+		}
+		attack += attack * p.BadgeTier() / 4
+		for _, t := range m.targets(i) {
+			m.Players[t].State.ReceiveGarbage(attack)
+			m.Players[t].lastAttacker = i
+		}
+	}
+}
+
+// eliminate places the players who topped out this frame, credits their KOs
+// and ends the match when at most one player is left.
+func (m *Match) eliminate() {
+	remaining := m.alive()
+	var out []int
+	for i, p := range m.Players {
+		if p.Alive() && p.State.GameOver {
+			out = append(out, i)
+		}
+	}
+	if len(out) == 0 {
+		return
+	}
+
+	// Players knocked out on the same frame share the place.
+	for _, i := range out {
+		p := m.Players[i]
+		p.Place = len(remaining) - len(out) + 1
+		if a := p.lastAttacker; a >= 0 && m.Players[a].Alive() && !m.Players[a].State.GameOver {
+			m.Players[a].KOs++
+			m.Players[a].Badges += 1 + p.Badges
+		}
+	}
+
+	left := m.alive()
+	switch len(left) {
+	case 0:
+		m.Over = true
+	case 1:
+		m.Over, m.Winner = true, left[0]
+		m.Players[left[0]].Place = 1
+	}
+}
+
+// alive returns the indices of the players still in the match.
+func (m *Match) alive() []int {
+	var out []int
+	for i, p := range m.Players {
+		if p.Alive() {
+			out = append(out, i)
+		}
+	}
+	return out
+}
@@ -0,0 +1,90 @@
+package royale
+
+import (
+	"errors"
+
+	"termino/internal/broadcast"
+	"termino/internal/game"
+	"termino/pkg/consts"
+)
+
+// Version is the protocol version, exchanged in hello.
+const Version = 1
+
+// ErrVersion is returned by Dial when the server speaks another version.
+var ErrVersion = errors.New("royale: incompatible protocol version")
+
+// Messages are JSON objects, one per line. The client starts with hello and
+// the server answers with welcome, followed by a welcome for every player
+// that joins the lobby and a state every frame once the match is running.
+const (
+	msgHello   = "hello"
+	msgWelcome = "welcome"
+	msgInput   = "input"
+	msgTarget  = "target"
+	msgState   = "state"
+	msgError   = "error"
+)
+
+type message struct {
+	Type     string      `json:"type"`
+	Version  int         `json:"version,omitempty"`
+	Name     string      `json:"name,omitempty"`
+	ID       int         `json:"id,omitempty"`      // Player index, in welcome
+	Waiting  int         `json:"waiting,omitempty"` // Players still expected, in welcome
+	Action   game.Action `json:"action,omitempty"`
+	Strategy string      `json:"strategy,omitempty"`
+	View     *View       `json:"view,omitempty"`
+	Error    string      `json:"error,omitempty"`
+}
+
+// View is what a player sees of the match: their own game in full and an
+// overview of every board.
+type View struct {
+	Frame   int                `json:"frame"`
+	You     int                `json:"you"`
+	Own     broadcast.Snapshot `json:"own"`
+	Players []Overview         `json:"players"`
+	Over    bool               `json:"over,omitempty"`
+	Winner  int                `json:"winner"`
+}
+
+// Overview is the compact state of a player's board.
+type Overview struct {
+	Name     string   `json:"name"`
+	Rows     []uint16 `json:"rows"` // Visible rows, top first, one bit per column
+	Pending  int      `json:"pending,omitempty"`
+	Place    int      `json:"place,omitempty"`
+	KOs      int      `json:"kos,omitempty"`
+	Badges   int      `json:"badges,omitempty"`
+	Target   int      `json:"target"`
+	Strategy string   `json:"strategy"`
+}
+
+// View returns the match as seen by player i.
+func (m *Match) View(i int) View {
+	v := View{
+		Frame:  m.Frame,
+		You:    i,
+		Own:    broadcast.Capture(m.Frame, &m.Players[i].State),
+		Over:   m.Over,
+		Winner: m.Winner,
+	}
+	for _, p := range m.Players {
+		rows := make([]uint16, consts.VisibleHeight)
+		for y := range rows {
+			rows[y] = uint16(p.State.Board[consts.BoardHeight-consts.VisibleHeight+y])
+		}
+		v.Players = append(v.Players, Overview{
+			Name:     p.Name,
+			Rows:     rows,
+			Pending:  p.State.PendingLines(),
+			Place:    p.Place,
+			KOs:      p.KOs,
+			Badges:   p.Badges,
+			Target:   p.Target,
+			Strategy: p.Strategy.String(),
+		})
+	}
+	return v
+}
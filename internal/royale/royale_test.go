@@ -0,0 +1,204 @@
+package royale
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"termino/internal/bot"
+	"termino/internal/game"
+)
+
+func newMatch(t *testing.T, players int) *Match {
+	t.Helper()
+	m := NewMatch(1)
+	for _, name := range []string{"A", "B", "C", "D"}[:players] {
+		m.Join(name, nil)
+	}
+	return m
+}
+
+func loadBoard(t *testing.T, g *game.GameState, board string) {
+	t.Helper()
+	if err := g.LoadBoard(board); err != nil {
+		t.Fatalf("LoadBoard failed: %v", err)
+	}
+}
+
+// tetrisBoard leaves an I piece one hard drop away from a tetris.
+const tetrisBoard = `
+current: I
+GGGGGGGGG.
+GGGGGGGGG.
+GGGGGGGGG.
+GGGGGGGGG.
+G.GGGGGGGG`
+
+func tetris(t *testing.T, m *Match, i int) {
+	t.Helper()
+	loadBoard(t, &m.Players[i].State, tetrisBoard)
+	m.Apply(i, game.ActionRotateCW)
+	m.Apply(i, game.ActionDASRight)
+	m.Apply(i, game.ActionHardDrop)
+}
+
+func TestTargeting_KOsPicksHighestStack(t *testing.T) {
+	m := newMatch(t, 3)
+	loadBoard(t, &m.Players[2].State, "GGGGGGGGG.\nGGGGGGGGG.\nGGGGGGGGG.")
+	m.SetStrategy(0, TargetKOs)
+
+	tetris(t, m, 0)
+	m.Step()
+
+	if m.Players[0].Target != 2 {
+		t.Errorf("targeting %d, want the highest stack (2)", m.Players[0].Target)
+	}
+	if got := m.Players[2].State.PendingLines(); got != 4 {
+		t.Errorf("player 2 has %d pending lines, want 4", got)
+	}
+	if got := m.Players[1].State.PendingLines(); got != 0 {
+		t.Errorf("player 1 has %d pending lines, want 0", got)
+	}
+}
+
+func TestTargeting_AttackersReceiveEverything(t *testing.T) {
+	m := newMatch(t, 4)
+	m.SetStrategy(0, TargetAttackers)
+	m.SetStrategy(1, TargetBadges)
+	m.SetStrategy(2, TargetBadges)
+	m.SetStrategy(3, TargetBadges)
+	m.Players[0].Badges = 5
+	m.Players[1].Badges = 1
+	m.Step()
+	if m.Players[2].Target != 0 || m.Players[3].Target != 0 {
+		t.Fatalf("targets %d and %d, want both on the badge leader (0)", m.Players[2].Target, m.Players[3].Target)
+	}
+
+	tetris(t, m, 0)
+	m.Step()
+
+	// Two badge points raise the attack by a quarter.
+	for i, want := range []int{0, 5, 5, 5} {
+		if got := m.Players[i].State.PendingLines(); got != want {
+			t.Errorf("player %d has %d pending lines, want %d", i, got, want)
+		}
+	}
+}
+
+func TestElimination_CreditsKOsAndEndsMatch(t *testing.T) {
+	m := newMatch(t, 3)
+	m.Players[1].Badges = 3
+	m.Players[1].lastAttacker = 0
+	m.Eliminate(1)
+	m.Step()
+
+	if m.Players[1].Place != 3 {
+		t.Errorf("first player out placed %d, want 3", m.Players[1].Place)
+	}
+	if p := m.Players[0]; p.KOs != 1 || p.Badges != 4 {
+		t.Errorf("attacker has %d KOs and %d badges, want 1 and 4", p.KOs, p.Badges)
+	}
+	if m.Over {
+		t.Fatal("match ended with two players left")
+	}
+
+	m.Eliminate(2)
+	m.Step()
+	if !m.Over || m.Winner != 0 || m.Players[0].Place != 1 || m.Players[2].Place != 2 {
+		t.Errorf("Over %v, winner %d, places %d and %d, want player 0 to win over player 2",
+			m.Over, m.Winner, m.Players[0].Place, m.Players[2].Place)
+	}
+}
+
+func TestServe_HumanAgainstBots(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	strength, _ := bot.StrengthByName("easy")
+	done := make(chan error, 1)
+	go func() {
+		done <- Serve(l, Config{Humans: 1, Bots: 2, Strength: strength, BotInterval: 3, Seed: 1})
+	}()
+
+	c, err := Dial(l.Addr().String(), "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	receive := func() message {
+		t.Helper()
+		select {
+		case msg, ok := <-c.incoming:
+			if !ok {
+				t.Fatalf("connection closed: %v", c.err)
+			}
+			return msg
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the server")
+		}
+		return message{}
+	}
+
+	if msg := receive(); msg.Type != msgWelcome || msg.Waiting != 0 {
+		t.Fatalf("got %+v, want a welcome with nobody else to wait for", msg)
+	}
+	msg := receive()
+	for msg.Type != msgState {
+		msg = receive()
+	}
+	if len(msg.View.Players) != 3 || msg.View.Players[0].Name != "alice" || msg.View.Players[2].Name != "BOT 2" {
+		t.Fatalf("players %+v, want alice and two bots", msg.View.Players)
+	}
+	own, _ := msg.View.Own.State()
+
+	c.Input(game.ActionDASLeft)
+	deadline := time.After(5 * time.Second)
+	for {
+		msg := receive()
+		state, _ := msg.View.Own.State()
+		if state.CurrentX < own.CurrentX {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("input never reached the server")
+		default:
+		}
+	}
+
+	c.Close()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Serve: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve kept running after the only human left")
+	}
+}
+
+func TestServe_RejectsOtherVersions(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go Serve(l, Config{Humans: 1})
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	json.NewEncoder(conn).Encode(message{Type: msgHello, Version: Version + 1})
+
+	var reply message
+	if err := json.NewDecoder(bufio.NewReader(conn)).Decode(&reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply.Type != msgError || reply.Version != Version {
+		t.Errorf("got %+v, want an error carrying version %d", reply, Version)
+	}
+}
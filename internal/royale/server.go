@@ -0,0 +1,231 @@
+package royale
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"termino/internal/bot"
+	"termino/pkg/consts"
+)
+
+// helloTimeout bounds the wait for a client's hello.
+const helloTimeout = 10 * time.Second
+
+// Config describes the match a server hosts.
+type Config struct {
+	Humans      int          // Players to wait for before starting
+	Bots        int          // Bots added when the match starts
+	Strength    bot.Strength // Strength of the bots
+	BotInterval int          // Frames between bot inputs
+	Seed        int64
+}
+
+// event is something a connection reports to the match loop.
+type event struct {
+	player int
+	msg    message
+	gone   bool // The player disconnected
+}
+
+// client is a human player's connection, written to from its own goroutine.
+type client struct {
+	name  string
+	conn  net.Conn
+	dec   *json.Decoder
+	queue chan []byte
+}
+
+// Serve accepts players on l, runs one match and returns when it is over or
+// every human has left.
+func Serve(l net.Listener, cfg Config) error {
+	m := NewMatch(cfg.Seed)
+	joins := make(chan *client)
+	events := make(chan event, 256)
+	var wg sync.WaitGroup
+
+	go accept(l, joins)
+
+	// Lobby: wait for the humans. Players who leave it are knocked out as
+	// soon as the match starts.
+	var clients []*client
+	var left []int
+	for len(clients) < cfg.Humans {
+		select {
+		case c, ok := <-joins:
+			if !ok {
+				for _, c := range clients {
+					c.conn.Close()
+				}
+				return fmt.Errorf("royale: listener closed with %d of %d players", len(clients), cfg.Humans)
+			}
+			id := len(clients)
+			clients = append(clients, c)
+			m.Join(c.name, nil)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c.write()
+			}()
+			go c.read(id, events)
+			for i, c := range clients {
+				c.send(message{Type: msgWelcome, ID: i, Waiting: cfg.Humans - len(clients)})
+			}
+		case e := <-events:
+			if e.gone {
+				left = append(left, e.player)
+			}
+		}
+	}
+	l.Close()
+	go func() {
+		for c := range joins {
+			c.reject(message{Type: msgError, Error: "the match has already started"})
+		}
+	}()
+
+	for i := range cfg.Bots {
+		pilot := cfg.Strength.Pilot(cfg.Seed+int64(i), cfg.BotInterval)
+		m.Join(fmt.Sprintf("BOT %d", i+1), &pilot)
+	}
+	for _, i := range left {
+		m.Eliminate(i)
+	}
+
+	connected := len(clients) - len(left)
+	ticker := time.NewTicker(time.Second / consts.TickRate)
+	defer ticker.Stop()
+	for !m.Over && connected > 0 {
+		select {
+		case e := <-events:
+			switch {
+			case e.gone:
+				connected--
+				m.Eliminate(e.player)
+			case e.msg.Type == msgInput:
+				m.Apply(e.player, e.msg.Action)
+			case e.msg.Type == msgTarget:
+				if s, err := ParseStrategy(e.msg.Strategy); err == nil {
+					m.SetStrategy(e.player, s)
+				}
+			}
+		case <-ticker.C:
+			m.Step()
+			for i, c := range clients {
+				v := m.View(i)
+				c.send(message{Type: msgState, View: &v})
+			}
+		}
+	}
+
+	for _, c := range clients {
+		close(c.queue)
+	}
+	wg.Wait()
+	return nil
+}
+
+// accept hands every connection that says hello to joins, until l is closed.
+func accept(l net.Listener, joins chan<- *client) {
+	var wg sync.WaitGroup
+	defer func() {
+		wg.Wait()
+		close(joins)
+	}()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if c, ok := greet(conn); ok {
+				joins <- c
+			}
+		}()
+	}
+}
+
+// greet reads the client's hello, turning it away if the versions differ.
+func greet(conn net.Conn) (*client, bool) {
+	c := &client{conn: conn, dec: json.NewDecoder(bufio.NewReader(conn)), queue: make(chan []byte, 16)}
+
+	conn.SetReadDeadline(time.Now().Add(helloTimeout))
+	var hello message
+	if err := c.dec.Decode(&hello); err != nil || hello.Type != msgHello {
+		conn.Close()
+		return nil, false
+	}
+	if hello.Version != Version {
+		c.reject(message{Type: msgError, Version: Version, Error: fmt.Sprintf("server has version %d", Version)})
+		return nil, false
+	}
+	conn.SetReadDeadline(time.Time{})
+
+	c.name = hello.Name
+	if c.name == "" {
+		c.name = conn.RemoteAddr().String()
+	}
+	return c, true
+}
+
+// reject sends an error to a client that is not playing and disconnects it.
+func (c *client) reject(msg message) {
+	json.NewEncoder(c.conn).Encode(msg)
+	c.conn.Close()
+}
+
+// read reports the client's messages as events until it disconnects.
+func (c *client) read(player int, events chan<- event) {
+	for {
+		var msg message
+		if err := c.dec.Decode(&msg); err != nil {
+			events <- event{player: player, gone: true}
+			return
+		}
+		events <- event{player: player, msg: msg}
+	}
+}
+
+// send queues a message, dropping the oldest queued one if the client falls
+// behind.
+func (c *client) send(msg message) {
+	line, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+	select {
+	case c.queue <- line:
+	default:
+		select {
+		case <-c.queue:
+		default:
+		}
+		select {
+		case c.queue <- line:
+		default:
+		}
+	}
+}
+
+// write sends queued messages until the queue is closed.
+func (c *client) write() {
+	defer c.conn.Close()
+	w := bufio.NewWriter(c.conn)
+	for line := range c.queue {
+		if _, err := w.Write(line); err != nil {
+			return
+		}
+		if len(c.queue) == 0 {
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	}
+	w.Flush()
+}
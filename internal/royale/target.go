@@ -0,0 +1,146 @@
+package royale
+
+import (
+	"fmt"
+
+	"termino/pkg/consts"
+)
+
+// Strategy decides whom a player's attacks go to.
+type Strategy int
+
+const (
+	TargetRandom    Strategy = iota // A random opponent, redrawn every few seconds
+	TargetAttackers                 // Everyone targeting the player, or a random opponent if nobody is
+	TargetKOs                       // The opponent closest to topping out
+	TargetBadges                    // The opponent with the most badges
+)
+
+var strategyNames = [...]string{"random", "attackers", "kos", "badges"}
+
+func (s Strategy) String() string {
+	if s < 0 || int(s) >= len(strategyNames) {
+		return fmt.Sprintf("Strategy(%d)", int(s))
+	}
+	return strategyNames[s]
+}
+
+// ParseStrategy returns the strategy with the given name.
+func ParseStrategy(name string) (Strategy, error) {
+	for i, n := range strategyNames {
+		if n == name {
+			return Strategy(i), nil
+		}
+	}
+	return 0, fmt.Errorf("royale: unknown targeting strategy %q", name)
+}
+
+// SetStrategy changes whom a player attacks.
+func (m *Match) SetStrategy(i int, s Strategy) {
+	p := m.Players[i]
+	if p.Strategy != s {
+		p.Strategy = s
+		p.retarget = 0
+	}
+}
+
+// chooseTargets updates the target every player attacks next.
+func (m *Match) chooseTargets() {
+	for i, p := range m.Players {
+		if !p.Alive() {
+			p.Target = -1
+			continue
+		}
+		if p.retarget > 0 {
+			p.retarget--
+		}
+
+		switch p.Strategy {
+		case TargetAttackers:
+			if attackers := m.attackers(i); len(attackers) > 0 {
+				p.Target = attackers[0]
+				continue
+			}
+		case TargetKOs:
+			p.Target = m.best(i, func(o *Player) int {
+				return stackHeight(o)*consts.BoardHeight + o.State.PendingLines()
+			})
+			continue
+		case TargetBadges:
+			p.Target = m.best(i, func(o *Player) int { return o.Badges })
+			continue
+		}
+
+		// Random targets are kept for a while unless they are knocked out.
+		if p.retarget == 0 || !m.validTarget(i, p.Target) {
+			p.Target = m.randomOpponent(i)
+			p.retarget = retargetFrames
+		}
+	}
+}
+
+// targets returns the players that receive player i's next attack.
+func (m *Match) targets(i int) []int {
+	p := m.Players[i]
+	if p.Strategy == TargetAttackers {
+		if attackers := m.attackers(i); len(attackers) > 0 {
+			return attackers
+		}
+	}
+	if !m.validTarget(i, p.Target) {
+		return nil
+	}
+	return []int{p.Target}
+}
+
+// attackers returns the players still in the match who target player i.
+func (m *Match) attackers(i int) []int {
+	var out []int
+	for j, o := range m.Players {
+		if j != i && o.Alive() && o.Target == i {
+			out = append(out, j)
+		}
+	}
+	return out
+}
+
+// best returns the opponent of player i that scores highest, breaking ties
+// at random, or -1 if there is none.
+func (m *Match) best(i int, score func(*Player) int) int {
+	target, top, ties := -1, 0, 0
+	for j, o := range m.Players {
+		if j == i || !o.Alive() {
+			continue
+		}
+		s := score(o)
+		switch {
+		case target < 0 || s > top:
+			target, top, ties = j, s, 1
+		case s == top:
+			// Reservoir sampling keeps every tied player equally likely.
+			ties++
+			if m.rng.Intn(ties) == 0 {
+				target = j
+			}
+		}
+	}
+	return target
+}
+
+func (m *Match) randomOpponent(i int) int {
+	return m.best(i, func(*Player) int { return 0 })
+}
+
+func (m *Match) validTarget(i, t int) bool {
+	return t >= 0 && t != i && m.Players[t].Alive()
+}
+
+// stackHeight returns the height of the highest block on the player's board.
+func stackHeight(p *Player) int {
+	for row, mask := range p.State.Board {
+		if mask != 0 {
+			return consts.BoardHeight - row
+		}
+	}
+	return 0
+}
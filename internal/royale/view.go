@@ -0,0 +1,253 @@
+package royale
+
+import (
+	"fmt"
+	"strings"
+
+	"termino/internal/game"
+	"termino/pkg/consts"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Size of an opponent's overview: a name line, the board at two rows per
+// character and a line of stats.
+const (
+	miniWidth  = consts.BoardWidth
+	miniHeight = consts.VisibleHeight/2 + 2
+	miniGap    = 2
+)
+
+var (
+	textStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	boldStyle     = textStyle.Bold(true)
+	blockStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#AAAAAA"))
+	emptyStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#333333"))
+	outStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("#444444"))
+	targetStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")).Bold(true)
+	attackerStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFF00"))
+	winStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+)
+
+type (
+	serverMsg       message
+	serverClosedMsg struct{ err error }
+)
+
+// Game is a player's view of a royale match: their board beside a compact
+// overview of every opponent.
+type Game struct {
+	Width  int
+	Height int
+
+	client           *Client
+	view             *View
+	own              game.GameState
+	waiting          int
+	status           string
+	lastSpacePressed bool
+}
+
+// NewGame creates the view for a player connected with c.
+func NewGame(c *Client) Game {
+	return Game{Width: 80, Height: 24, client: c}
+}
+
+func (g Game) Init() tea.Cmd {
+	return g.wait()
+}
+
+// wait delivers the next message from the server.
+func (g Game) wait() tea.Cmd {
+	c := g.client
+	return func() tea.Msg {
+		msg, ok := <-c.incoming
+		if !ok {
+			return serverClosedMsg{c.err}
+		}
+		return serverMsg(msg)
+	}
+}
+
+// strategyKeys select a targeting strategy.
+var strategyKeys = map[string]Strategy{
+	"1": TargetRandom,
+	"2": TargetAttackers,
+	"3": TargetKOs,
+	"4": TargetBadges,
+}
+
+func (g Game) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		g.Width = msg.Width
+		g.Height = msg.Height
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return g, tea.Quit
+		}
+		if g.view == nil || g.view.Over || g.own.GameOver {
+			return g, nil
+		}
+		if s, ok := strategyKeys[msg.String()]; ok {
+			g.client.Target(s)
+			return g, nil
+		}
+		action, ok := game.DefaultKeymap[msg.String()]
+		if !ok {
+			return g, nil
+		}
+		if action == game.ActionHardDrop {
+			if g.lastSpacePressed {
+				return g, nil
+			}
+			g.lastSpacePressed = true
+		}
+		g.client.Input(action)
+
+	case serverMsg:
+		switch msg.Type {
+		case msgWelcome:
+			g.waiting = msg.Waiting
+		case msgState:
+			g.lastSpacePressed = false
+			own, err := msg.View.Own.State()
+			if err != nil {
+				g.status = "Bad state: " + err.Error()
+				break
+			}
+			g.view, g.own = msg.View, own
+		case msgError:
+			g.status = msg.Error
+		}
+		return g, g.wait()
+
+	case serverClosedMsg:
+		if g.view == nil || !g.view.Over {
+			g.status = "Disconnected from the server"
+		}
+	}
+	return g, nil
+}
+
+func (g Game) View() string {
+	if g.view == nil {
+		if g.status != "" {
+			return g.status + "\n"
+		}
+		return fmt.Sprintf("Waiting for %d more player(s)...\n", g.waiting)
+	}
+	v := g.view
+	game.PrepareScreen(g.Width, g.Height)
+	w, h := game.ScreenBuffer.Width(), game.ScreenBuffer.Height()
+
+	// Lay out the overviews in as many rows as fit beside the board.
+	opponents := len(v.Players) - 1
+	rows := max(min(h/miniHeight, opponents), 1)
+	cols := (opponents + rows - 1) / rows
+	cols = max(min(cols, (w-game.GameWidth)/(miniWidth+miniGap)), 0)
+
+	total := game.GameWidth + cols*(miniWidth+miniGap)
+	left := max((w-total)/2, 0)
+	x := left + game.GameLeft
+	y := max((h-(consts.VisibleHeight+2))/2, 0)
+	// The single-player game over screen offers a retry, so the result of the
+	// match is drawn instead.
+	own := g.own
+	own.GameOver = false
+	game.DrawGameAt(&own, x, y)
+
+	me := v.Players[v.You]
+	if y > 0 {
+		game.DrawText(x+1+(consts.BoardWidth*2-3)/2, y-1, "YOU", boldStyle)
+	}
+	if me.Place > 0 {
+		result, style := fmt.Sprintf("#%d of %d", me.Place, len(v.Players)), targetStyle
+		if v.Winner == v.You {
+			result, style = "VICTORY!", winStyle
+		}
+		game.ScreenBuffer.DimArea(x+1, y+1, consts.BoardWidth*2, consts.VisibleHeight)
+		game.DrawText(x+1+(consts.BoardWidth*2-len(result))/2, y+8, result, style)
+	}
+
+	status := fmt.Sprintf("Target: %s [1-4]  KOs: %d  Badges: %s", me.Strategy, me.KOs, badges(me.Badges))
+	if g.status != "" {
+		status = g.status
+	}
+	if y+consts.VisibleHeight+2 < h {
+		game.DrawText(x-game.GameLeft, y+consts.VisibleHeight+2, status, textStyle)
+	}
+
+	miniX := left + game.GameWidth + miniGap
+	miniY := max((h-rows*miniHeight)/2, 0)
+	n := 0
+	for i, p := range v.Players {
+		if i == v.You {
+			continue
+		}
+		col, row := n/rows, n%rows
+		n++
+		if col >= cols {
+			break
+		}
+		drawOverview(p, i, v, miniX+col*(miniWidth+miniGap), miniY+row*miniHeight)
+	}
+	return game.ScreenBuffer.Render()
+}
+
+// drawOverview draws player i's board at two rows per character, with their
+// name above it and their place or KOs below.
+func drawOverview(p Overview, i int, v *View, x, y int) {
+	nameStyle := textStyle
+	switch {
+	case p.Place > 0:
+		nameStyle = outStyle
+	case v.Players[v.You].Target == i:
+		nameStyle = targetStyle
+	case p.Target == v.You:
+		nameStyle = attackerStyle
+	}
+	name := p.Name
+	if len(name) > miniWidth {
+		name = name[:miniWidth]
+	}
+	game.DrawText(x, y, name, nameStyle)
+
+	for r := 0; r+1 < len(p.Rows); r += 2 {
+		for c := range consts.BoardWidth {
+			top := p.Rows[r]&(1<<c) != 0
+			bottom := p.Rows[r+1]&(1<<c) != 0
+			cell, style := "·", emptyStyle
+			switch {
+			case top && bottom:
+				cell, style = "█", blockStyle
+			case top:
+				cell, style = "▀", blockStyle
+			case bottom:
+				cell, style = "▄", blockStyle
+			}
+			if p.Place > 0 {
+				style = outStyle
+			}
+			game.DrawText(x+c, y+1+r/2, cell, style)
+		}
+	}
+
+	stats := fmt.Sprintf("KO %d %s", p.KOs, badges(p.Badges))
+	if p.Place > 0 {
+		stats = fmt.Sprintf("#%d", p.Place)
+	}
+	game.DrawText(x, y+miniHeight-1, stats, nameStyle)
+}
+
+// badges draws a star for every badge tier reached.
+func badges(points int) string {
+	p := Player{Badges: points}
+	if tier := p.BadgeTier(); tier > 0 {
+		return strings.Repeat("★", tier)
+	}
+	return "-"
+}
@@ -0,0 +1,103 @@
+package scores
+
+import (
+	"fmt"
+	"strings"
+
+	"termino/internal/game"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	finishedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+	overStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")).Bold(true)
+)
+
+// renderResults draws the final statistics of the game, why it ended and how
+// it compares with the personal best and the table, centred on the screen.
+func (p Play) renderResults() string {
+	g := &p.State
+	heading := overStyle.Render(strings.ToUpper(string(p.mode())) + " - GAME OVER")
+	if g.Completed {
+		heading = finishedStyle.Render(strings.ToUpper(string(p.mode())) + " - FINISHED")
+	}
+	lines := []string{heading, textStyle.Render(g.EndReason()), ""}
+
+	row := func(label, value string) {
+		lines = append(lines, textStyle.Render(fmt.Sprintf("%-10s %12s", label, value)))
+	}
+	row("Score", fmt.Sprint(g.Score))
+	row("Lines", fmt.Sprint(g.LinesCleared))
+	row("Level", fmt.Sprint(g.Level))
+	row("Time", game.FormatTime(g.Elapsed))
+	row("Pieces", fmt.Sprint(g.PiecesPlaced))
+	row("PPS", fmt.Sprintf("%.2f", g.PPS()))
+	row("KPP", fmt.Sprintf("%.2f", g.KPP()))
+	row("APM", fmt.Sprintf("%.1f", g.APM()))
+	row("Finesse", fmt.Sprintf("%.0f%%", g.FinessePercent()))
+	row("Max combo", fmt.Sprint(g.Stats.MaxCombo))
+	row("Max B2B", fmt.Sprint(g.Stats.MaxB2B))
+	lines = append(lines, "")
+
+	if p.History != nil {
+		best, style := p.personalBest()
+		lines = append(lines, style.Render("Best       "+best))
+	}
+	if p.rank >= 0 {
+		lines = append(lines, newStyle.Render(fmt.Sprintf("Table      #%d", p.rank+1)))
+	} else {
+		lines = append(lines, dimStyle.Render("Table      not ranked"))
+	}
+	if p.saved != "" {
+		lines = append(lines, "", textStyle.Render(p.saved))
+	}
+
+	quit := "q quit"
+	if p.QuitHint != "" {
+		quit = "q menu"
+	}
+	lines = append(lines, "",
+		dimStyle.Render("r retry same seed   n new seed   s save replay"),
+		dimStyle.Render("t high scores   "+quit))
+
+	block := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	if p.Width == 0 || p.Height == 0 {
+		return block
+	}
+	return lipgloss.Place(p.Width, p.Height, lipgloss.Center, lipgloss.Center, block)
+}
+
+// personalBest describes the best earlier game of the mode and the finished
+// game's difference from it.
+func (p Play) personalBest() (string, lipgloss.Style) {
+	g := &p.State
+	if !p.comparable() {
+		return "no personal best for this goal", dimStyle
+	}
+	if p.mode() == game.ModeSprint {
+		switch {
+		case !p.hasBest && g.Completed:
+			return "new personal best!", newStyle
+		case !p.hasBest:
+			return "no finished sprint yet", dimStyle
+		case !g.Completed:
+			return game.FormatTime(p.best.Time), textStyle
+		}
+		was := game.FormatTime(p.best.Time)
+		d := g.Elapsed - p.best.Time
+		if d < 0 {
+			return fmt.Sprintf("new personal best! -%s (was %s)", game.FormatTime(-d), was), newStyle
+		}
+		return fmt.Sprintf("%s (+%s)", was, game.FormatTime(d)), textStyle
+	}
+
+	if !p.hasBest {
+		return "new personal best!", newStyle
+	}
+	d := g.Score - p.best.Score
+	if d > 0 {
+		return fmt.Sprintf("new personal best! +%d (was %d)", d, p.best.Score), newStyle
+	}
+	return fmt.Sprintf("%d (%+d)", p.best.Score, d), textStyle
+}
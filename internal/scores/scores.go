@@ -0,0 +1,113 @@
+// Package scores keeps the local high-score tables, one per game mode, in the
+// data directory.
+package scores
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"termino/internal/game"
+	"termino/internal/store"
+)
+
+// File is the name of the score file in the data directory.
+const File = "scores.json"
+
+// TableSize is the number of entries kept per mode.
+const TableSize = 10
+
+// Entry is a finished game on a high-score table.
+type Entry struct {
+	Name  string        `json:"name"`
+	Score int           `json:"score"`
+	Lines int           `json:"lines"`
+	Time  time.Duration `json:"time"`
+	Date  time.Time     `json:"date"`
+}
+
+// Tables holds the high scores of every mode.
+type Tables struct {
+	LastName string                `json:"last_name,omitempty"` // Name entered last, offered again
+	Modes    map[game.Mode][]Entry `json:"modes"`
+}
+
+// Path returns the location of the score file.
+func Path() (string, error) {
+	return store.Path(File)
+}
+
+// Load reads the tables at path. A corrupt file is moved aside and empty
+// tables are returned together with store.ErrCorrupt.
+func Load(path string) (*Tables, error) {
+	t := &Tables{}
+	err := store.Load(path, t)
+	if err != nil && !errors.Is(err, store.ErrCorrupt) {
+		return nil, err
+	}
+	if t.Modes == nil || errors.Is(err, store.ErrCorrupt) {
+		t.Modes = map[game.Mode][]Entry{}
+	}
+	return t, err
+}
+
+// Save writes the tables to path atomically.
+func (t *Tables) Save(path string) error {
+	return store.Save(path, t)
+}
+
+// Eligible reports whether a finished game may enter its mode's table. Sprint
+// times only count when all the lines of the standard goal were cleared.
+func Eligible(g *game.GameState) bool {
+	if g.Mode == game.ModeSprint {
+		return g.Completed && g.Goal() == game.SprintLines
+	}
+	return g.Score > 0
+}
+
+// EntryFor returns the entry recording a finished game under name.
+func EntryFor(g *game.GameState, name string) Entry {
+	return Entry{
+		Name:  name,
+		Score: g.Score,
+		Lines: g.LinesCleared,
+		Time:  g.Elapsed,
+		Date:  time.Now(),
+	}
+}
+
+// better reports whether a ranks above b in mode: the fastest sprint, or the
+// highest score in the other modes.
+func better(mode game.Mode, a, b Entry) bool {
+	if mode == game.ModeSprint {
+		return a.Time < b.Time
+	}
+	return a.Score > b.Score
+}
+
+// Rank returns the position, counted from 0, that e would take in mode's
+// table, or -1 if it would not make the table. Ties rank below earlier entries.
+func (t *Tables) Rank(mode game.Mode, e Entry) int {
+	entries := t.Modes[mode]
+	i := slices.IndexFunc(entries, func(old Entry) bool { return better(mode, e, old) })
+	if i < 0 {
+		i = len(entries)
+	}
+	if i >= TableSize {
+		return -1
+	}
+	return i
+}
+
+// Add records e in mode's table and returns its position, or -1 if it did not
+// make the table.
+func (t *Tables) Add(mode game.Mode, e Entry) int {
+	i := t.Rank(mode, e)
+	if i < 0 {
+		return -1
+	}
+	entries := slices.Insert(t.Modes[mode], i, e)
+	t.Modes[mode] = entries[:min(len(entries), TableSize)]
+	t.LastName = e.Name
+	return i
+}
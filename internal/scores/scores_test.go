@@ -0,0 +1,276 @@
+package scores
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"termino/internal/game"
+	"termino/internal/stats"
+	"termino/internal/store"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestRank_SprintByTime(t *testing.T) {
+	tables := &Tables{Modes: map[game.Mode][]Entry{}}
+	tables.Add(game.ModeSprint, Entry{Name: "a", Time: 60 * time.Second})
+	tables.Add(game.ModeSprint, Entry{Name: "b", Time: 40 * time.Second})
+	tables.Add(game.ModeSprint, Entry{Name: "c", Time: 50 * time.Second})
+
+	var names string
+	for _, e := range tables.Modes[game.ModeSprint] {
+		names += e.Name
+	}
+	if names != "bca" {
+		t.Errorf("sprint order = %q, want %q", names, "bca")
+	}
+}
+
+func TestRank_ScoreModes(t *testing.T) {
+	tables := &Tables{Modes: map[game.Mode][]Entry{}}
+	tables.Add(game.ModeUltra, Entry{Name: "a", Score: 100})
+	tables.Add(game.ModeUltra, Entry{Name: "b", Score: 300})
+
+	if got := tables.Rank(game.ModeUltra, Entry{Score: 200}); got != 1 {
+		t.Errorf("Rank(200) = %d, want 1", got)
+	}
+	// Ties rank below the entries already on the table.
+	if got := tables.Rank(game.ModeUltra, Entry{Score: 300}); got != 1 {
+		t.Errorf("Rank(300) = %d, want 1", got)
+	}
+	if got := tables.Rank(game.ModeMarathon, Entry{Score: 1}); got != 0 {
+		t.Errorf("Rank on an empty table = %d, want 0", got)
+	}
+}
+
+func TestAdd_KeepsTableSize(t *testing.T) {
+	tables := &Tables{Modes: map[game.Mode][]Entry{}}
+	for i := range TableSize + 5 {
+		tables.Add(game.ModeMarathon, Entry{Score: (i + 1) * 10})
+	}
+	entries := tables.Modes[game.ModeMarathon]
+	if len(entries) != TableSize {
+		t.Fatalf("table has %d entries, want %d", len(entries), TableSize)
+	}
+	if entries[0].Score != 150 || entries[TableSize-1].Score != 60 {
+		t.Errorf("table runs %d..%d, want 150..60", entries[0].Score, entries[TableSize-1].Score)
+	}
+	if got := tables.Add(game.ModeMarathon, Entry{Name: "low", Score: 5}); got != -1 {
+		t.Errorf("Add(5) = %d, want -1", got)
+	}
+	if tables.LastName == "low" {
+		t.Error("an entry that missed the table changed LastName")
+	}
+}
+
+func TestEligible(t *testing.T) {
+	g := game.NewGameStateWithSeed(1)
+	g.Mode = game.ModeSprint
+	g.Score = 500
+	if Eligible(&g) {
+		t.Error("an unfinished sprint is eligible")
+	}
+	g.Completed = true
+	if !Eligible(&g) {
+		t.Error("a finished sprint is not eligible")
+	}
+	g.LineGoal = 20
+	if Eligible(&g) {
+		t.Error("a sprint to 20 lines is eligible")
+	}
+
+	g.Mode = game.ModeMarathon
+	g.Score = 0
+	if Eligible(&g) {
+		t.Error("a marathon without points is eligible")
+	}
+}
+
+func TestSaveLoad_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), File)
+	tables := &Tables{Modes: map[game.Mode][]Entry{}}
+	date := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tables.Add(game.ModeSprint, Entry{Name: "ann", Lines: 40, Time: 83 * time.Second, Date: date})
+	if err := tables.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.LastName != "ann" {
+		t.Errorf("LastName = %q, want ann", got.LastName)
+	}
+	entries := got.Modes[game.ModeSprint]
+	if len(entries) != 1 || entries[0].Time != 83*time.Second || !entries[0].Date.Equal(date) {
+		t.Errorf("sprint table = %+v", entries)
+	}
+}
+
+func TestLoad_Missing(t *testing.T) {
+	tables, err := Load(filepath.Join(t.TempDir(), File))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tables.Modes == nil {
+		t.Error("Modes is nil")
+	}
+}
+
+func TestLoad_CorruptStartsAfresh(t *testing.T) {
+	path := filepath.Join(t.TempDir(), File)
+	if err := os.WriteFile(path, []byte(`{"modes": {"sprint": [`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tables, err := Load(path)
+	if !errors.Is(err, store.ErrCorrupt) {
+		t.Fatalf("Load error = %v, want ErrCorrupt", err)
+	}
+	if tables == nil || len(tables.Modes) != 0 {
+		t.Fatalf("tables = %+v, want empty", tables)
+	}
+	tables.Add(game.ModeMarathon, Entry{Name: "b", Score: 10})
+	if err := tables.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path + ".corrupt"); err != nil {
+		t.Errorf("corrupt file was not kept: %v", err)
+	}
+}
+
+func TestPlay_NameEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), File)
+	tables := &Tables{LastName: "al", Modes: map[game.Mode][]Entry{}}
+	m := game.NewModelWithMode(1, game.ModeMarathon)
+	m.State.Score = 1200
+	m.State.GameOver = true
+	var p tea.Model = NewPlay(m, tables, path)
+
+	p, _ = p.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("ex")},
+		{Type: tea.KeyBackspace},
+		{Type: tea.KeyRunes, Runes: []rune("x")},
+		{Type: tea.KeyEnter},
+	} {
+		p, _ = p.Update(key)
+	}
+
+	if err := p.(Play).Err; err != nil {
+		t.Fatal(err)
+	}
+	saved, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := saved.Modes[game.ModeMarathon]
+	if len(entries) != 1 || entries[0].Name != "alex" || entries[0].Score != 1200 {
+		t.Errorf("saved marathon table = %+v, want alex with 1200", entries)
+	}
+	if view := ansi.Strip(p.View()); !strings.Contains(view, "#1") {
+		t.Errorf("results screen does not show the rank:\n%s", view)
+	}
+	p, _ = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	if view := ansi.Strip(p.View()); !strings.Contains(view, "alex") {
+		t.Errorf("table screen does not show the new entry:\n%s", view)
+	}
+}
+
+func TestPlay_NameEntryQuits(t *testing.T) {
+	tables := &Tables{Modes: map[game.Mode][]Entry{}}
+	m := game.NewModelWithMode(1, game.ModeMarathon)
+	m.State.Score = 1200
+	m.State.GameOver = true
+	var p tea.Model = NewPlay(m, tables, filepath.Join(t.TempDir(), File))
+
+	p, _ = p.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	if p.(Play).stage != naming {
+		t.Fatal("Expected to be asked for a name")
+	}
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	if cmd == nil {
+		t.Fatal("Expected ctrl+c to quit while entering a name")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("Expected ctrl+c to quit while entering a name")
+	}
+}
+
+func TestPlay_Results(t *testing.T) {
+	tables := &Tables{Modes: map[game.Mode][]Entry{}}
+	history := &stats.History{Games: []stats.Record{
+		{Mode: game.ModeSprint, Completed: true, Time: 90 * time.Second},
+		{Mode: game.ModeSprint, Completed: true, Time: 80 * time.Second},
+	}}
+	play := NewPlay(game.NewModelWithMode(5, game.ModeSprint), tables, filepath.Join(t.TempDir(), File))
+	play.History = history
+	var saved *game.Replay
+	play.SaveReplay = func(r *game.Replay) (string, error) {
+		saved = r
+		return "replay.json", nil
+	}
+	p, _ := play.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+
+	// A sprint that tops out makes no table but still has results.
+	play = p.(Play)
+	play.State.LinesCleared = 12
+	play.State.Elapsed = 70 * time.Second
+	play.State.GameOver = true
+	p, _ = play.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	view := ansi.Strip(p.View())
+	for _, want := range []string{"SPRINT - GAME OVER", "1:10.00", "1:20.00", "not ranked"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("results screen does not show %q:\n%s", want, view)
+		}
+	}
+
+	p, _ = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if saved == nil || !strings.Contains(ansi.Strip(p.View()), "Replay saved to replay.json") {
+		t.Error("the replay was not saved")
+	}
+
+	p, _ = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if play := p.(Play); play.stage != playing || play.State.Seed != 5 || play.State.GameOver {
+		t.Errorf("after retrying: stage %d, seed %d, game over %v; want a new game with seed 5", play.stage, play.State.Seed, play.State.GameOver)
+	}
+}
+
+func TestPlay_NoPersonalBestForOtherGoals(t *testing.T) {
+	tables := &Tables{Modes: map[game.Mode][]Entry{}}
+	history := &stats.History{Games: []stats.Record{
+		{Mode: game.ModeSprint, Completed: true, Time: 80 * time.Second},
+	}}
+	play := NewPlay(game.NewModelWithSetup(game.Setup{Mode: game.ModeSprint, Seed: 5, LineGoal: 10}), tables, filepath.Join(t.TempDir(), File))
+	play.History = history
+	p, _ := play.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+
+	play = p.(Play)
+	play.State.LinesCleared = 10
+	play.State.Elapsed = 30 * time.Second
+	play.State.Completed, play.State.GameOver = true, true
+	p, _ = play.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	view := ansi.Strip(p.View())
+	if !strings.Contains(view, "no personal best for this goal") || strings.Contains(view, "new personal best") || strings.Contains(view, "1:20.00") {
+		t.Errorf("a 10-line sprint was compared with 40-line sprints:\n%s", view)
+	}
+}
+
+func TestPlay_SkipsBoardGames(t *testing.T) {
+	tables := &Tables{Modes: map[game.Mode][]Entry{}}
+	m := game.NewModelFromState(game.NewGameStateWithSeed(1))
+	m.State.Score = 1200
+	m.State.GameOver = true
+	var p tea.Model = NewPlay(m, tables, filepath.Join(t.TempDir(), File))
+
+	p, _ = p.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	if p.(Play).stage != playing {
+		t.Error("a game from a prepared board asked for a name")
+	}
+}
@@ -0,0 +1,304 @@
+package scores
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"termino/internal/game"
+	"termino/internal/stats"
+	"termino/pkg/consts"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxNameLength limits the names entered on the game over screen.
+const maxNameLength = 12
+
+var (
+	textStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	titleStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFF00")).Bold(true)
+	newStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+	dimStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+)
+
+type stage int
+
+const (
+	playing stage = iota
+	naming        // Entering a name for a new high score
+	results       // Showing the results of the game
+	showing       // Showing the tables after a game
+)
+
+// Play runs a game and, when it ends, shows its results. A high score is
+// first given a name and saved.
+type Play struct {
+	game.Model
+	Tables     *Tables
+	Path       string                               // Score file written after every new entry
+	Err        error                                // Last error saving the tables
+	History    *stats.History                       // Earlier games, for the personal best; none if nil
+	SaveReplay func(r *game.Replay) (string, error) // Keeps the replay of the game, returning where
+
+	stage     stage
+	judged    bool // The current game over was checked for a high score
+	name      []rune
+	rank      int
+	viewing   game.Mode
+	best      stats.Record // Personal best before the current game
+	hasBest   bool
+	bestKnown bool   // best was looked up for the current game
+	saved     string // Result of saving the replay
+}
+
+// NewPlay records the high scores of games played with m in tables, saving
+// them to path.
+func NewPlay(m game.Model, tables *Tables, path string) Play {
+	return Play{Model: m, Tables: tables, Path: path, rank: -1}
+}
+
+func (p Play) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch p.stage {
+		case naming:
+			return p, p.typeName(key)
+		case results:
+			return p.chooseResult(key)
+		case showing:
+			switch key.String() {
+			case "left", "right":
+				p.viewing = cycleMode(p.viewing, key.String() == "right")
+			case "ctrl+c":
+				return p, tea.Quit
+			case "t", "q", "esc", "enter":
+				p.stage = results
+			}
+			return p, nil
+		}
+	}
+
+	next, cmd := p.Model.Update(msg)
+	p.Model = next.(game.Model)
+
+	switch {
+	case !p.State.GameOver:
+		p.judged = false
+		if !p.bestKnown && p.History != nil && p.comparable() {
+			p.best, p.hasBest = p.History.Best(p.mode())
+		}
+		p.bestKnown = true
+	case !p.judged:
+		p.judged = true
+		p.judge()
+	}
+	return p, cmd
+}
+
+// judge shows the results of a finished game, first asking for a name if it
+// makes its table. Bot games and games from prepared boards are left alone.
+func (p *Play) judge() {
+	if p.Bot != nil || p.Replay == nil {
+		return
+	}
+	p.stage, p.rank, p.saved, p.bestKnown = results, -1, "", false
+	if !Eligible(&p.State) || p.Tables.Rank(p.mode(), EntryFor(&p.State, "")) < 0 {
+		return
+	}
+	p.stage = naming
+	p.name = []rune(p.Tables.LastName)
+}
+
+// chooseResult acts on a key pressed on the results screen.
+func (p Play) chooseResult(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch key.String() {
+	case "r":
+		p.RestartWithSeed(p.State.Seed)
+		p.stage = playing
+	case "n":
+		p.RestartWithSeed(time.Now().UnixNano())
+		p.stage = playing
+	case "s":
+		p.saveReplay()
+	case "t":
+		p.stage, p.viewing = showing, p.mode()
+	case "ctrl+c", "q", "esc":
+		return p, tea.Quit
+	}
+	return p, nil
+}
+
+// saveReplay keeps the replay of the finished game, once.
+func (p *Play) saveReplay() {
+	if p.SaveReplay == nil || p.saved != "" {
+		return
+	}
+	path, err := p.SaveReplay(p.Replay)
+	if err != nil {
+		p.saved = err.Error()
+		return
+	}
+	p.saved = "Replay saved to " + path
+}
+
+// typeName edits the name being entered and saves the entry on enter.
+func (p *Play) typeName(key tea.KeyMsg) tea.Cmd {
+	switch key.Type {
+	case tea.KeyCtrlC:
+		return tea.Quit
+	case tea.KeyEnter:
+		name := strings.TrimSpace(string(p.name))
+		if name == "" {
+			return nil
+		}
+		p.rank = p.Tables.Add(p.mode(), EntryFor(&p.State, name))
+		p.Err = p.Tables.Save(p.Path)
+		p.stage = results
+	case tea.KeyEsc:
+		p.stage = results
+	case tea.KeyBackspace:
+		if len(p.name) > 0 {
+			p.name = p.name[:len(p.name)-1]
+		}
+	case tea.KeySpace:
+		if len(p.name) < maxNameLength {
+			p.name = append(p.name, ' ')
+		}
+	case tea.KeyRunes:
+		for _, r := range key.Runes {
+			if unicode.IsPrint(r) && len(p.name) < maxNameLength {
+				p.name = append(p.name, r)
+			}
+		}
+	}
+	return nil
+}
+
+// comparable reports whether the game can be compared with the history, which
+// only keeps sprints to the standard goal, as the tables do.
+func (p Play) comparable() bool {
+	return p.mode() != game.ModeSprint || p.State.Goal() == game.SprintLines
+}
+
+func (p Play) mode() game.Mode {
+	if p.State.Mode == "" {
+		return game.ModeMarathon
+	}
+	return p.State.Mode
+}
+
+func (p Play) View() string {
+	switch p.stage {
+	case results:
+		return p.renderResults()
+	case showing:
+		highlight := -1
+		if p.viewing == p.mode() {
+			highlight = p.rank
+		}
+		return renderTables(p.Tables, p.viewing, highlight, "←/→ mode   esc back", p.Width, p.Height)
+	case naming:
+		p.Model.View()
+		x, y := game.BoardOrigin(game.ScreenBuffer.Width(), game.ScreenBuffer.Height())
+		rank := p.Tables.Rank(p.mode(), EntryFor(&p.State, ""))
+		game.DrawText(x+2, y+consts.VisibleHeight-5, fmt.Sprintf("New record! #%d", rank+1), newStyle)
+		game.DrawText(x+2, y+consts.VisibleHeight-3, "Name: "+string(p.name)+"_", textStyle)
+		game.DrawText(x+2, y+consts.VisibleHeight-1, "Enter saves", dimStyle)
+		return game.ScreenBuffer.Render()
+	}
+	return p.Model.View()
+}
+
+// Board is the high-score screen on its own.
+type Board struct {
+	Tables  *Tables
+	Width   int
+	Height  int
+	viewing game.Mode
+}
+
+// NewBoard shows tables, starting with mode.
+func NewBoard(tables *Tables, mode game.Mode) Board {
+	return Board{Tables: tables, Width: 80, Height: 24, viewing: mode}
+}
+
+func (b Board) Init() tea.Cmd {
+	return nil
+}
+
+func (b Board) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		b.Width, b.Height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "left", "right":
+			b.viewing = cycleMode(b.viewing, msg.String() == "right")
+		case "ctrl+c", "q", "esc":
+			return b, tea.Quit
+		}
+	}
+	return b, nil
+}
+
+func (b Board) View() string {
+	return renderTables(b.Tables, b.viewing, -1, "←/→ mode   q quit", b.Width, b.Height)
+}
+
+// cycleMode returns the mode after or before mode.
+func cycleMode(mode game.Mode, forward bool) game.Mode {
+	i := max(slices.Index(game.Modes, mode), 0)
+	if forward {
+		i++
+	} else {
+		i += len(game.Modes) - 1
+	}
+	return game.Modes[i%len(game.Modes)]
+}
+
+// renderTables draws mode's table centred on the screen, with tabs for the
+// other modes. The entry at highlight, if any, stands out.
+func renderTables(t *Tables, mode game.Mode, highlight int, help string, w, h int) string {
+	var tabs []string
+	for _, m := range game.Modes {
+		name := strings.ToUpper(string(m))
+		if m == mode {
+			tabs = append(tabs, titleStyle.Render("["+name+"]"))
+		} else {
+			tabs = append(tabs, dimStyle.Render(" "+name+" "))
+		}
+	}
+
+	lines := []string{titleStyle.Render("HIGH SCORES"), "", strings.Join(tabs, " "), ""}
+	if mode == game.ModeSprint {
+		lines = append(lines, dimStyle.Render(fmt.Sprintf(" #  %-12s %9s %6s  %s", "Name", "Time", "Lines", "Date")))
+	} else {
+		lines = append(lines, dimStyle.Render(fmt.Sprintf(" #  %-12s %9s %6s  %s", "Name", "Score", "Lines", "Date")))
+	}
+
+	entries := t.Modes[mode]
+	if len(entries) == 0 {
+		lines = append(lines, textStyle.Render("No records yet."))
+	}
+	for i, e := range entries {
+		value := fmt.Sprint(e.Score)
+		if mode == game.ModeSprint {
+			value = game.FormatTime(e.Time)
+		}
+		style := textStyle
+		if i == highlight {
+			style = newStyle
+		}
+		lines = append(lines, style.Render(fmt.Sprintf("%2d. %-12s %9s %6d  %s", i+1, e.Name, value, e.Lines, e.Date.Format("2006-01-02"))))
+	}
+	lines = append(lines, "", dimStyle.Render(help))
+
+	block := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	if w == 0 || h == 0 {
+		return block
+	}
+	return lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center, block)
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"termino/internal/store"
+)
+
+// leaderboardSize is the number of entries the leaderboard keeps.
+const leaderboardSize = 10
+
+// Entry is a finished game on the leaderboard.
+type Entry struct {
+	Name  string    `json:"name"`
+	Score int       `json:"score"`
+	Lines int       `json:"lines"`
+	Level int       `json:"level"`
+	Date  time.Time `json:"date"`
+}
+
+// Leaderboard holds the best scores of all sessions. It is saved to a file
+// after every change when it was loaded from one.
+type Leaderboard struct {
+	mu      sync.Mutex
+	path    string
+	entries []Entry
+}
+
+// LoadLeaderboard reads the leaderboard kept at path. An empty path keeps the
+// leaderboard in memory only.
+func LoadLeaderboard(path string) (*Leaderboard, error) {
+	lb := &Leaderboard{path: path}
+	if path == "" {
+		return lb, nil
+	}
+	if err := store.Load(path, &lb.entries); err != nil {
+		return lb, err
+	}
+	return lb, nil
+}
+
+// Submit adds a finished game and reports whether it made the leaderboard.
+func (lb *Leaderboard) Submit(e Entry) (bool, error) {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	// Equal scores keep the earlier entry first.
+	i := slices.IndexFunc(lb.entries, func(old Entry) bool { return old.Score < e.Score })
+	if i < 0 {
+		i = len(lb.entries)
+	}
+	if i >= leaderboardSize {
+		return false, nil
+	}
+	lb.entries = slices.Insert(lb.entries, i, e)
+	lb.entries = lb.entries[:min(len(lb.entries), leaderboardSize)]
+
+	if lb.path == "" {
+		return true, nil
+	}
+	return true, store.Save(lb.path, lb.entries)
+}
+
+// Top returns up to n entries, best first.
+func (lb *Leaderboard) Top(n int) []Entry {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	return slices.Clone(lb.entries[:min(n, len(lb.entries))])
+}
+
+// Lines formats up to n entries as lines of text no wider than width.
+func (lb *Leaderboard) Lines(n, width int) []string {
+	var lines []string
+	for i, e := range lb.Top(n) {
+		score := fmt.Sprint(e.Score)
+		name := e.Name
+		if room := width - len(score) - 4; len(name) > room {
+			name = name[:max(room, 0)]
+		}
+		pad := max(width-4-len(name)-len(score), 1)
+		lines = append(lines, fmt.Sprintf("%2d. %s%s%s", i+1, name, strings.Repeat(" ", pad), score))
+	}
+	return lines
+}
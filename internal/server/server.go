@@ -0,0 +1,149 @@
+// Package server hosts termino over SSH. Every session plays its own game,
+// finished games go on a shared leaderboard, and sessions can watch the games
+// of others.
+package server
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"termino/internal/game"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	bm "github.com/charmbracelet/wish/bubbletea"
+	"github.com/charmbracelet/wish/logging"
+)
+
+// Commands that may be passed to the server, as in `ssh -p 2222 host watch`.
+const (
+	CommandPlay   = ""
+	CommandWatch  = "watch"
+	CommandScores = "scores"
+)
+
+// Server tracks the games of all SSH sessions.
+type Server struct {
+	Leaderboard *Leaderboard
+
+	mu     sync.Mutex
+	games  map[int]*liveGame
+	nextID int
+}
+
+// liveGame is the latest state of a session's game, published for spectators.
+type liveGame struct {
+	id    int
+	name  string
+	state game.GameState
+}
+
+// gameKey stores the id of a session's game in its context.
+type gameKey struct{}
+
+// New creates a server that records finished games on lb.
+func New(lb *Leaderboard) *Server {
+	return &Server{Leaderboard: lb, games: map[int]*liveGame{}}
+}
+
+// SSH returns an SSH server listening on addr that identifies itself with the
+// host key at hostKeyPath, generating the key if the file does not exist.
+// Any user may connect; the user name is the name on the leaderboard.
+func (s *Server) SSH(addr, hostKeyPath string) (*ssh.Server, error) {
+	return wish.NewServer(
+		wish.WithAddress(addr),
+		wish.WithHostKeyPath(hostKeyPath),
+		wish.WithMiddleware(
+			bm.Middleware(s.program),
+			s.commands,
+			logging.Middleware(),
+		),
+	)
+}
+
+// commands answers the commands that need no terminal and rejects unknown
+// ones, passing the rest on.
+func (s *Server) commands(next ssh.Handler) ssh.Handler {
+	return func(sess ssh.Session) {
+		var cmd string
+		if args := sess.Command(); len(args) > 0 {
+			cmd = args[0]
+		}
+
+		switch cmd {
+		case CommandPlay, CommandWatch:
+			next(sess)
+			if id, ok := sess.Context().Value(gameKey{}).(int); ok {
+				s.unregister(id)
+			}
+		case CommandScores:
+			lines := s.Leaderboard.Lines(leaderboardSize, 32)
+			if len(lines) == 0 {
+				wish.Println(sess, "No scores yet.")
+			}
+			for _, line := range lines {
+				wish.Println(sess, line)
+			}
+		default:
+			wish.Fatalln(sess, fmt.Sprintf("unknown command %q; use %q, %q or none to play", cmd, CommandWatch, CommandScores))
+		}
+	}
+}
+
+// program creates the Bubbletea model for an interactive session.
+func (s *Server) program(sess ssh.Session) (tea.Model, []tea.ProgramOption) {
+	pty, _, ok := sess.Pty()
+	if !ok {
+		wish.Fatalln(sess, "termino needs a terminal; connect with ssh -t")
+		return nil, nil
+	}
+	opts := []tea.ProgramOption{tea.WithAltScreen()}
+
+	if args := sess.Command(); len(args) > 0 && args[0] == CommandWatch {
+		return newWatchModel(s, pty.Window.Width, pty.Window.Height), opts
+	}
+
+	id := s.register(sess.User())
+	sess.Context().SetValue(gameKey{}, id)
+	return newPlayModel(s, id, sess.User(), pty.Window.Width, pty.Window.Height), opts
+}
+
+func (s *Server) register(name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	s.games[s.nextID] = &liveGame{id: s.nextID, name: name}
+	return s.nextID
+}
+
+func (s *Server) unregister(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.games, id)
+}
+
+// publish records the current state of a session's game for spectators.
+func (s *Server) publish(id int, state *game.GameState) {
+	snapshot := *state
+	snapshot.NextQueue = slices.Clone(state.NextQueue)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if g, ok := s.games[id]; ok {
+		g.state = snapshot
+	}
+}
+
+// live returns the games in progress in the order the sessions joined.
+func (s *Server) live() []liveGame {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	games := make([]liveGame, 0, len(s.games))
+	for _, g := range s.games {
+		games = append(games, *g)
+	}
+	slices.SortFunc(games, func(a, b liveGame) int { return a.id - b.id })
+	return games
+}
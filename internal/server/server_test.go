@@ -0,0 +1,191 @@
+package server
+
+import (
+	"bytes"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/wish/testsession"
+	"github.com/charmbracelet/x/ansi"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func TestLeaderboard_KeepsBestScores(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "leaderboard.json")
+	lb, err := LoadLeaderboard(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range leaderboardSize + 2 {
+		if _, err := lb.Submit(Entry{Name: "p", Score: i * 100}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if made, _ := lb.Submit(Entry{Name: "low", Score: 50}); made {
+		t.Error("Expected a score below the full leaderboard to miss it")
+	}
+	if made, _ := lb.Submit(Entry{Name: "tie", Score: 1100}); !made {
+		t.Error("Expected a tied score to make the leaderboard")
+	}
+
+	reloaded, err := LoadLeaderboard(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	top := reloaded.Top(leaderboardSize + 5)
+	if len(top) != leaderboardSize {
+		t.Fatalf("Expected %d entries, got %d", leaderboardSize, len(top))
+	}
+	if top[0].Score != 1100 || top[0].Name != "p" || top[1].Name != "tie" {
+		t.Errorf("Expected the earlier of two equal scores first, got %+v, %+v", top[0], top[1])
+	}
+	if top[leaderboardSize-1].Score != 300 {
+		t.Errorf("Expected the lowest kept score to be 300, got %d", top[leaderboardSize-1].Score)
+	}
+}
+
+func newTestServer(t *testing.T) (*Server, string) {
+	t.Helper()
+	lb, _ := LoadLeaderboard("")
+	s := New(lb)
+	srv, err := s.SSH("", filepath.Join(t.TempDir(), "host_key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, testsession.Listen(t, srv)
+}
+
+func TestSSH_Scores(t *testing.T) {
+	s, addr := newTestServer(t)
+	s.Leaderboard.Submit(Entry{Name: "alice", Score: 1234})
+
+	sess, err := testsession.NewClientSession(t, addr, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := sess.Output(CommandScores)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "alice") || !strings.Contains(string(out), "1234") {
+		t.Errorf("Expected alice's score in the output, got %q", out)
+	}
+}
+
+func TestSSH_UnknownCommand(t *testing.T) {
+	_, addr := newTestServer(t)
+
+	sess, err := testsession.NewClientSession(t, addr, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stderr bytes.Buffer
+	sess.Stderr = &stderr
+	if err := sess.Run("fly"); err == nil {
+		t.Error("Expected an unknown command to fail")
+	}
+	if !strings.Contains(stderr.String(), "unknown command") {
+		t.Errorf("Expected an explanation on stderr, got %q", stderr.String())
+	}
+}
+
+func TestSSH_PlaySession(t *testing.T) {
+	s, addr := newTestServer(t)
+
+	sess, err := testsession.NewClientSession(t, addr, &gossh.ClientConfig{User: "bob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sess.RequestPty("xterm-256color", 24, 80, gossh.TerminalModes{}); err != nil {
+		t.Fatal(err)
+	}
+	stdin, err := sess.StdinPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stdout bytes.Buffer
+	sess.Stdout = &stdout
+	if err := sess.Shell(); err != nil {
+		t.Fatal(err)
+	}
+
+	waitFor(t, "the game to be published", func() bool {
+		games := s.live()
+		return len(games) == 1 && games[0].name == "bob"
+	})
+
+	stdin.Write([]byte("q"))
+	done := make(chan error, 1)
+	go func() { done <- sess.Wait() }()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected q to end the session")
+	}
+	waitFor(t, "the game to be removed", func() bool { return len(s.live()) == 0 })
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWatch_SwitchesBetweenGames(t *testing.T) {
+	lb, _ := LoadLeaderboard("")
+	s := New(lb)
+	newPlayModel(s, s.register("alice"), "alice", 80, 24)
+	newPlayModel(s, s.register("bob"), "bob", 80, 24)
+
+	var m tea.Model = newWatchModel(s, 80, 24)
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "alice (1/2)") {
+		t.Fatalf("Expected to watch alice first, got:\n%s", view)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "bob (2/2)") {
+		t.Errorf("Expected to watch bob after switching, got:\n%s", view)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "alice (1/2)") {
+		t.Errorf("Expected switching to wrap around to alice, got:\n%s", view)
+	}
+}
+
+func TestSessions_DrawIntoOwnBuffers(t *testing.T) {
+	lb, _ := LoadLeaderboard("")
+	s := New(lb)
+	sizes := [][2]int{{80, 24}, {100, 30}, {60, 26}}
+
+	views := make(chan string, len(sizes)*10)
+	for _, size := range sizes {
+		m := newPlayModel(s, s.register("player"), "player", size[0], size[1])
+		go func() {
+			for range 10 {
+				views <- ansi.Strip(m.View())
+			}
+		}()
+	}
+	for range len(sizes) * 10 {
+		view := <-views
+		lines := strings.Split(view, "\n")
+		width := len([]rune(lines[0]))
+		if !slices.ContainsFunc(sizes, func(size [2]int) bool { return size == [2]int{width, len(lines)} }) {
+			t.Fatalf("Expected a view of one session's size, got %dx%d", width, len(lines))
+		}
+		for _, line := range lines {
+			if n := len([]rune(line)); n != width {
+				t.Fatalf("Expected every line %d wide, got %d:\n%s", width, n, view)
+			}
+		}
+	}
+}
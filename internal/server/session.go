@@ -0,0 +1,163 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"termino/internal/bot"
+	"termino/internal/game"
+	"termino/internal/render"
+	"termino/pkg/consts"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	textStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	titleStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFF00")).Bold(true)
+)
+
+// drawLeaderboard writes the leaderboard into the top-left corner of b.
+func drawLeaderboard(b *render.Buffer, lb *Leaderboard) {
+	game.DrawTextOn(b, 1, 0, "Leaderboard", titleStyle)
+	for i, line := range lb.Lines(leaderboardSize, 18) {
+		game.DrawTextOn(b, 1, i+1, line, textStyle)
+	}
+}
+
+// playModel is a session's own game, drawn into a buffer of its own. Its state
+// is published for spectators and finished games are submitted to the
+// leaderboard.
+type playModel struct {
+	game.Model
+	srv       *Server
+	id        int
+	name      string
+	submitted bool
+}
+
+func newPlayModel(srv *Server, id int, name string, width, height int) playModel {
+	m := playModel{Model: game.NewModel(), srv: srv, id: id, name: name}
+	m.Width, m.Height = width, height
+	m.Screen = render.NewBuffer(width, height)
+	m.Hints = bot.New(bot.DefaultConfig)
+	srv.publish(id, &m.State)
+	return m
+}
+
+func (m playModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.Model.Update(msg)
+	m.Model = next.(game.Model)
+	m.srv.publish(m.id, &m.State)
+
+	switch {
+	case m.State.GameOver && !m.submitted:
+		m.submitted = true
+		_, err := m.srv.Leaderboard.Submit(Entry{
+			Name:  m.name,
+			Score: m.State.Score,
+			Lines: m.State.LinesCleared,
+			Level: m.State.Level,
+			Date:  time.Now(),
+		})
+		if err != nil {
+			log.Printf("saving leaderboard: %v", err)
+		}
+	case !m.State.GameOver:
+		m.submitted = false
+	}
+	return m, cmd
+}
+
+func (m playModel) View() string {
+	m.Model.View()
+	if m.State.GameOver {
+		drawLeaderboard(m.Screen, m.srv.Leaderboard)
+	}
+	return m.Screen.Render()
+}
+
+type refreshMsg struct{}
+
+func refresh() tea.Cmd {
+	return tea.Tick(time.Second/30, func(time.Time) tea.Msg { return refreshMsg{} })
+}
+
+// watchModel spectates the games of other sessions, switching between them
+// with the arrow keys.
+type watchModel struct {
+	srv      *Server
+	screen   *render.Buffer
+	width    int
+	height   int
+	watching int // Session watched, 0 for the first game in progress
+}
+
+func newWatchModel(srv *Server, width, height int) watchModel {
+	return watchModel{srv: srv, screen: render.NewBuffer(width, height), width: width, height: height}
+}
+
+func (m watchModel) Init() tea.Cmd {
+	return refresh()
+}
+
+func (m watchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		case "left", "right":
+			games := m.srv.live()
+			if len(games) == 0 {
+				break
+			}
+			i := m.current(games)
+			if msg.String() == "left" {
+				i = (i + len(games) - 1) % len(games)
+			} else {
+				i = (i + 1) % len(games)
+			}
+			m.watching = games[i].id
+		}
+	case refreshMsg:
+		return m, refresh()
+	}
+	return m, nil
+}
+
+// current returns the index of the watched game among games, falling back to
+// the first one when that session has left.
+func (m watchModel) current(games []liveGame) int {
+	for i, g := range games {
+		if g.id == m.watching {
+			return i
+		}
+	}
+	return 0
+}
+
+func (m watchModel) View() string {
+	b := m.screen
+	games := m.srv.live()
+	if len(games) == 0 {
+		game.PrepareBuffer(b, m.width, m.height)
+		drawLeaderboard(b, m.srv.Leaderboard)
+		game.DrawTextOn(b, 1, leaderboardSize+2, "No games in progress. Press q to quit.", textStyle)
+		return b.Render()
+	}
+
+	i := m.current(games)
+	g := games[i]
+	xs, y := game.DrawGamesOn(b, []*game.GameState{&g.state}, m.width, m.height)
+	drawLeaderboard(b, m.srv.Leaderboard)
+
+	header := fmt.Sprintf("%s (%d/%d)", g.name, i+1, len(games))
+	game.DrawTextOn(b, xs[0]+1+(consts.BoardWidth*2-len(header))/2, max(y-1, 0), header, titleStyle)
+	game.DrawTextOn(b, xs[0]-1, y+consts.VisibleHeight+2, "left/right switch games, q quits", textStyle)
+	return b.Render()
+}
@@ -0,0 +1,137 @@
+// Package settings holds the player's tunables, such as the lock delay, the
+// preview length and the colour palette, and keeps them in a config file in
+// the data directory.
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"termino/internal/store"
+	"termino/pkg/consts"
+)
+
+// File is the name of the settings file in the data directory.
+const File = "settings.json"
+
+// Defaults, with delays in milliseconds.
+const (
+	DefaultLockDelay = 500
+	DefaultPreview   = 3
+	DefaultPalette   = "standard"
+)
+
+// Accepted ranges, with delays in milliseconds.
+const (
+	MinLockDelay = 100
+	MaxLockDelay = 5000
+	MaxPreview   = consts.PreviewCount
+)
+
+// Palettes lists the colour palettes, the default first.
+var Palettes = []string{"standard", "pastel", "mono"}
+
+// paletteColors holds each palette's colour per piece, except the standard
+// palette, which uses the pieces' own colours.
+var paletteColors = map[string]map[string]string{
+	"pastel": {
+		"I": "#8BE9FD", "J": "#8FA8F8", "L": "#FFB86C", "O": "#F1FA8C",
+		"S": "#A6E3A1", "T": "#CBA6F7", "Z": "#F38BA8",
+	},
+	"mono": {
+		"I": "#FFFFFF", "J": "#B0B0B0", "L": "#D8D8D8", "O": "#F0F0F0",
+		"S": "#C8C8C8", "T": "#E0E0E0", "Z": "#A0A0A0",
+	},
+}
+
+// ErrInvalid is wrapped by the errors of Validate.
+var ErrInvalid = errors.New("settings: invalid value")
+
+// Settings are the player's tunables. Delays are in milliseconds so that the
+// file is easy to edit by hand.
+type Settings struct {
+	LockDelay int    `json:"lock_delay_ms"` // Time a grounded piece waits before locking
+	Preview   int    `json:"preview"`       // Next pieces shown, up to MaxPreview
+	Ghost     bool   `json:"ghost"`         // Show where the piece will land
+	Palette   string `json:"palette"`       // One of Palettes
+}
+
+// Default returns the settings used when there is no settings file.
+func Default() Settings {
+	return Settings{
+		LockDelay: DefaultLockDelay,
+		Preview:   DefaultPreview,
+		Ghost:     true,
+		Palette:   DefaultPalette,
+	}
+}
+
+// LockDelayDuration returns the lock delay as a duration.
+func (s Settings) LockDelayDuration() time.Duration {
+	return time.Duration(s.LockDelay) * time.Millisecond
+}
+
+// Validate reports every setting outside its accepted range.
+func (s Settings) Validate() error {
+	var errs []error
+	check := func(name string, v, lo, hi int) {
+		if v < lo || v > hi {
+			errs = append(errs, fmt.Errorf("%w: %s %d is outside %d..%d", ErrInvalid, name, v, lo, hi))
+		}
+	}
+	check("lock_delay_ms", s.LockDelay, MinLockDelay, MaxLockDelay)
+	check("preview", s.Preview, 0, MaxPreview)
+	if !slices.Contains(Palettes, s.Palette) {
+		errs = append(errs, fmt.Errorf("%w: unknown palette %q", ErrInvalid, s.Palette))
+	}
+	return errors.Join(errs...)
+}
+
+// Fixed returns s with the settings outside their ranges reset to their
+// defaults.
+func (s Settings) Fixed() Settings {
+	d := Default()
+	if s.LockDelay < MinLockDelay || s.LockDelay > MaxLockDelay {
+		s.LockDelay = d.LockDelay
+	}
+	if s.Preview < 0 || s.Preview > MaxPreview {
+		s.Preview = d.Preview
+	}
+	if !slices.Contains(Palettes, s.Palette) {
+		s.Palette = d.Palette
+	}
+	return s
+}
+
+// PieceColor returns the colour of the named piece in the palette, or false
+// if the palette keeps the piece's own colour.
+func PieceColor(palette, piece string) (string, bool) {
+	c, ok := paletteColors[palette][piece]
+	return c, ok
+}
+
+// Path returns the location of the settings file.
+func Path() (string, error) {
+	return store.Path(File)
+}
+
+// Load reads the settings at path. Settings missing from the file keep their
+// defaults. Invalid settings are replaced by their defaults and reported in
+// the error, as is a corrupt file, which is moved aside.
+func Load(path string) (Settings, error) {
+	s := Default()
+	if err := store.Load(path, &s); err != nil {
+		return Default(), err
+	}
+	if err := s.Validate(); err != nil {
+		return s.Fixed(), fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Save writes the settings to path atomically.
+func (s Settings) Save(path string) error {
+	return store.Save(path, s)
+}
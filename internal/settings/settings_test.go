@@ -0,0 +1,77 @@
+package settings
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDefault_IsValid(t *testing.T) {
+	if err := Default().Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidate_ReportsEveryProblem(t *testing.T) {
+	s := Default()
+	s.Preview = -1
+	s.LockDelay = MaxLockDelay + 1
+	s.Palette = "neon"
+
+	err := s.Validate()
+	if !errors.Is(err, ErrInvalid) {
+		t.Fatalf("Validate = %v, want ErrInvalid", err)
+	}
+	if n := len(err.(interface{ Unwrap() []error }).Unwrap()); n != 3 {
+		t.Errorf("Validate reported %d problems, want 3: %v", n, err)
+	}
+
+	fixed := s.Fixed()
+	if fixed.Preview != DefaultPreview || fixed.LockDelay != DefaultLockDelay || fixed.Palette != DefaultPalette {
+		t.Errorf("Fixed = %+v, want the invalid settings reset", fixed)
+	}
+}
+
+func TestLoad_PartialFileKeepsDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), File)
+	if err := os.WriteFile(path, []byte(`{"preview": 5, "ghost": false}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Default()
+	want.Preview, want.Ghost = 5, false
+	if s != want {
+		t.Errorf("Load = %+v, want %+v", s, want)
+	}
+}
+
+func TestLoad_InvalidValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), File)
+	if err := os.WriteFile(path, []byte(`{"lock_delay_ms": 9000, "preview": 2}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Load(path)
+	if !errors.Is(err, ErrInvalid) {
+		t.Errorf("Load error = %v, want ErrInvalid", err)
+	}
+	if s.LockDelay != DefaultLockDelay || s.Preview != 2 {
+		t.Errorf("Load = %+v, want the default lock delay and preview 2", s)
+	}
+}
+
+func TestSaveLoad_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), File)
+	s := Default()
+	s.LockDelay, s.Palette = 800, "mono"
+	if err := s.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Load(path)
+	if err != nil || got != s {
+		t.Errorf("Load = %+v, %v, want %+v", got, err, s)
+	}
+}
@@ -0,0 +1,167 @@
+package stats
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"termino/internal/game"
+)
+
+// metric is a per-game figure summarised by the report.
+type metric struct {
+	name        string
+	lowerBetter bool
+	value       func(Record) float64
+	format      func(float64) string
+}
+
+func decimal(v float64) string { return fmt.Sprintf("%.2f", v) }
+func whole(v float64) string   { return fmt.Sprintf("%.0f", v) }
+func percent(v float64) string { return fmt.Sprintf("%.0f%%", v) }
+func clock(v float64) string   { return game.FormatTime(time.Duration(v * float64(time.Second))) }
+
+var metrics = []metric{
+	{name: "score", value: func(r Record) float64 { return float64(r.Score) }, format: whole},
+	{name: "lines", value: func(r Record) float64 { return float64(r.Lines) }, format: whole},
+	{name: "time", value: func(r Record) float64 { return r.Time.Seconds() }, format: clock},
+	{name: "PPS", value: Record.PPS, format: decimal},
+	{name: "KPP", lowerBetter: true, value: Record.KPP, format: decimal},
+	{name: "APM", value: Record.APM, format: decimal},
+	{name: "finesse", value: Record.Finesse, format: percent},
+	{name: "max combo", value: func(r Record) float64 { return float64(r.MaxCombo) }, format: whole},
+	{name: "max B2B", value: func(r Record) float64 { return float64(r.MaxB2B) }, format: whole},
+}
+
+// sprintTime replaces the time metric in sprint, where a lower time is better
+// and only completed sprints count.
+var sprintTime = metric{name: "time", lowerBetter: true, value: func(r Record) float64 { return r.Time.Seconds() }, format: clock}
+
+// Report writes, for each mode with recorded games, the average, best and
+// trend of every metric over its last n games, followed by the totals of
+// holds and clears. The trend is the average of the newer half of those
+// games minus that of the older half.
+func Report(w io.Writer, h *History, n int) {
+	first := true
+	for _, mode := range game.Modes {
+		games := h.Last(mode, n)
+		if len(games) == 0 {
+			continue
+		}
+		if !first {
+			fmt.Fprintln(w)
+		}
+		first = false
+		reportMode(w, mode, games)
+	}
+	if first {
+		fmt.Fprintln(w, "No games recorded yet.")
+	}
+}
+
+func reportMode(w io.Writer, mode game.Mode, games []Record) {
+	plural := "s"
+	if len(games) == 1 {
+		plural = ""
+	}
+	fmt.Fprintf(w, "%s: last %d game%s\n", strings.ToUpper(string(mode)), len(games), plural)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "\tavg\tbest\ttrend\t")
+	for _, m := range metrics {
+		values := games
+		if m.name == "time" && mode == game.ModeSprint {
+			m = sprintTime
+			values = completed(games)
+			if len(values) == 0 {
+				continue
+			}
+		}
+		avg, best, trend, ok := summarise(m, values)
+		t := "-"
+		if ok {
+			t = signed(m, trend)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t\n", m.name, m.format(avg), m.format(best), t)
+	}
+	tw.Flush()
+
+	var total Record
+	clears := map[string]int{}
+	for _, r := range games {
+		total.Pieces += r.Pieces
+		total.Holds += r.Holds
+		total.Attack += r.Attack
+		for kind, n := range r.Clears {
+			clears[kind] += n
+		}
+	}
+	fmt.Fprintf(w, "pieces %d, holds %d, attack %d\n", total.Pieces, total.Holds, total.Attack)
+
+	var parts []string
+	for kind := range game.NumClearKinds {
+		if n := clears[kind.String()]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", kind, n))
+		}
+	}
+	if len(parts) > 0 {
+		fmt.Fprintf(w, "clears: %s\n", strings.Join(parts, ", "))
+	}
+}
+
+// summarise returns the average and best of m over games, and the trend if
+// there are at least two games.
+func summarise(m metric, games []Record) (avg, best, trend float64, ok bool) {
+	best = m.value(games[0])
+	for _, r := range games {
+		v := m.value(r)
+		avg += v
+		if (m.lowerBetter && v < best) || (!m.lowerBetter && v > best) {
+			best = v
+		}
+	}
+	avg /= float64(len(games))
+	if len(games) < 2 {
+		return avg, best, 0, false
+	}
+	half := len(games) / 2
+	return avg, best, mean(m, games[len(games)-half:]) - mean(m, games[:half]), true
+}
+
+func mean(m metric, games []Record) float64 {
+	var sum float64
+	for _, r := range games {
+		sum += m.value(r)
+	}
+	return sum / float64(len(games))
+}
+
+// signed formats a trend with its sign, followed by whether it is an
+// improvement.
+func signed(m metric, trend float64) string {
+	sign := "+"
+	v := trend
+	if trend < 0 {
+		sign, v = "-", -trend
+	}
+	s := sign + m.format(v)
+	if m.format(v) == m.format(0) {
+		return s
+	}
+	if (trend > 0) != m.lowerBetter {
+		return s + " better"
+	}
+	return s + " worse"
+}
+
+func completed(games []Record) []Record {
+	var done []Record
+	for _, r := range games {
+		if r.Completed {
+			done = append(done, r)
+		}
+	}
+	return done
+}
@@ -0,0 +1,175 @@
+// Package stats keeps the statistics of finished games in a history file in
+// the data directory and summarises them.
+package stats
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"termino/internal/game"
+	"termino/internal/store"
+)
+
+// File is the name of the history file in the data directory.
+const File = "history.json"
+
+// MaxGames is the number of games kept in the history; older ones are dropped.
+const MaxGames = 1000
+
+// Record holds the statistics of one finished game.
+type Record struct {
+	Date          time.Time      `json:"date"`
+	Mode          game.Mode      `json:"mode"`
+	Seed          int64          `json:"seed"`
+	Completed     bool           `json:"completed,omitempty"` // The mode's goal was reached
+	Score         int            `json:"score"`
+	Lines         int            `json:"lines"`
+	Level         int            `json:"level"`
+	Pieces        int            `json:"pieces"`
+	Inputs        int            `json:"inputs"`
+	Holds         int            `json:"holds"`
+	Attack        int            `json:"attack"`
+	Clears        map[string]int `json:"clears,omitempty"` // Locks by game.ClearKind name
+	MaxCombo      int            `json:"max_combo"`
+	MaxB2B        int            `json:"max_b2b"`
+	FinessePieces int            `json:"finesse_pieces"`
+	FinesseFaults int            `json:"finesse_faults"`
+	Time          time.Duration  `json:"time"`
+}
+
+// FromGame records the statistics of a finished game.
+func FromGame(g *game.GameState) Record {
+	mode := g.Mode
+	if mode == "" {
+		mode = game.ModeMarathon
+	}
+	r := Record{
+		Date:          time.Now(),
+		Mode:          mode,
+		Seed:          g.Seed,
+		Completed:     g.Completed,
+		Score:         g.Score,
+		Lines:         g.LinesCleared,
+		Level:         g.Level,
+		Pieces:        g.PiecesPlaced,
+		Inputs:        g.Stats.Inputs,
+		Holds:         g.Stats.Holds,
+		Attack:        g.AttackSent,
+		MaxCombo:      g.Stats.MaxCombo,
+		MaxB2B:        g.Stats.MaxB2B,
+		FinessePieces: g.FinessePieces,
+		FinesseFaults: g.FinesseFaults,
+		Time:          g.Elapsed,
+	}
+	for kind, n := range g.Stats.Clears {
+		if n > 0 {
+			if r.Clears == nil {
+				r.Clears = map[string]int{}
+			}
+			r.Clears[game.ClearKind(kind).String()] = n
+		}
+	}
+	return r
+}
+
+// PPS returns the pieces placed per second.
+func (r Record) PPS() float64 {
+	if r.Time <= 0 {
+		return 0
+	}
+	return float64(r.Pieces) / r.Time.Seconds()
+}
+
+// KPP returns the inputs per piece placed.
+func (r Record) KPP() float64 {
+	if r.Pieces == 0 {
+		return 0
+	}
+	return float64(r.Inputs) / float64(r.Pieces)
+}
+
+// APM returns the garbage lines sent per minute.
+func (r Record) APM() float64 {
+	if r.Time <= 0 {
+		return 0
+	}
+	return float64(r.Attack) / r.Time.Minutes()
+}
+
+// Finesse returns the percentage of judged pieces placed with perfect
+// finesse, or 0 if none were judged.
+func (r Record) Finesse() float64 {
+	if r.FinessePieces == 0 {
+		return 0
+	}
+	return 100 * float64(r.FinessePieces-r.FinesseFaults) / float64(r.FinessePieces)
+}
+
+// History is the list of recorded games, oldest first.
+type History struct {
+	Games []Record `json:"games"`
+}
+
+// Path returns the location of the history file.
+func Path() (string, error) {
+	return store.Path(File)
+}
+
+// Load reads the history at path. A corrupt file is moved aside and an empty
+// history is returned together with store.ErrCorrupt.
+func Load(path string) (*History, error) {
+	h := &History{}
+	err := store.Load(path, h)
+	if errors.Is(err, store.ErrCorrupt) {
+		return &History{}, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Save writes the history to path atomically.
+func (h *History) Save(path string) error {
+	return store.Save(path, h)
+}
+
+// Add appends r, dropping the oldest games beyond MaxGames.
+func (h *History) Add(r Record) {
+	h.Games = append(h.Games, r)
+	if over := len(h.Games) - MaxGames; over > 0 {
+		h.Games = h.Games[over:]
+	}
+}
+
+// Last returns up to n of the most recent games of mode, oldest first.
+func (h *History) Last(mode game.Mode, n int) []Record {
+	var games []Record
+	for i := len(h.Games) - 1; i >= 0 && len(games) < n; i-- {
+		if h.Games[i].Mode == mode {
+			games = append(games, h.Games[i])
+		}
+	}
+	slices.Reverse(games)
+	return games
+}
+
+// Best returns the best game of mode: the fastest completed sprint, or the
+// highest score in the other modes. ok is false if there is none.
+func (h *History) Best(mode game.Mode) (best Record, ok bool) {
+	for _, r := range h.Games {
+		if r.Mode != mode {
+			continue
+		}
+		switch {
+		case mode == game.ModeSprint:
+			if r.Completed && (!ok || r.Time < best.Time) {
+				best, ok = r, true
+			}
+		case !ok || r.Score > best.Score:
+			best, ok = r, true
+		}
+	}
+	return best, ok
+}
@@ -0,0 +1,136 @@
+package stats
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"termino/internal/game"
+	"termino/internal/store"
+)
+
+func TestFromGame(t *testing.T) {
+	g := game.NewGameStateWithSeed(1)
+	if err := g.LoadBoard(`
+GGGGGGGGGi
+GGGGGGGGGi
+GGGGGGGGGi
+GGGGGGGGGi`); err != nil {
+		t.Fatal(err)
+	}
+	g.Apply(game.ActionLeft) // Blocked, but still an input
+	g.Apply(game.ActionLeft)
+	g.Apply(game.ActionHardDrop)
+	g.Elapsed = 2 * time.Second
+
+	r := FromGame(&g)
+	if r.Mode != game.ModeMarathon || r.Pieces != 1 || r.Lines != 4 || r.Inputs != 3 {
+		t.Errorf("record = %+v", r)
+	}
+	if r.Clears["tetris"] != 1 || r.Clears["perfect_clear"] != 1 || len(r.Clears) != 2 {
+		t.Errorf("Clears = %v, want a tetris and a perfect clear", r.Clears)
+	}
+	if r.PPS() != 0.5 || r.KPP() != 3 || r.APM() != float64(r.Attack)*30 {
+		t.Errorf("PPS = %v, KPP = %v, APM = %v", r.PPS(), r.KPP(), r.APM())
+	}
+}
+
+func TestHistory_AddDropsOldest(t *testing.T) {
+	var h History
+	for i := range MaxGames + 3 {
+		h.Add(Record{Mode: game.ModeMarathon, Score: i})
+	}
+	if len(h.Games) != MaxGames || h.Games[0].Score != 3 {
+		t.Errorf("history has %d games starting at %d, want %d starting at 3", len(h.Games), h.Games[0].Score, MaxGames)
+	}
+}
+
+func TestHistory_Last(t *testing.T) {
+	var h History
+	for i, mode := range []game.Mode{game.ModeSprint, game.ModeMarathon, game.ModeSprint, game.ModeSprint} {
+		h.Add(Record{Mode: mode, Score: i})
+	}
+	got := h.Last(game.ModeSprint, 2)
+	if len(got) != 2 || got[0].Score != 2 || got[1].Score != 3 {
+		t.Errorf("Last = %+v, want scores 2 and 3", got)
+	}
+}
+
+func TestHistory_Best(t *testing.T) {
+	h := History{Games: []Record{
+		{Mode: game.ModeSprint, Completed: true, Time: 90 * time.Second},
+		{Mode: game.ModeSprint, Time: 30 * time.Second},
+		{Mode: game.ModeSprint, Completed: true, Time: 80 * time.Second},
+		{Mode: game.ModeMarathon, Score: 500},
+		{Mode: game.ModeMarathon, Score: 300},
+	}}
+	if best, ok := h.Best(game.ModeSprint); !ok || best.Time != 80*time.Second {
+		t.Errorf("best sprint = %v, %v; want the completed one in 1:20", best.Time, ok)
+	}
+	if best, ok := h.Best(game.ModeMarathon); !ok || best.Score != 500 {
+		t.Errorf("best marathon = %d, %v; want 500", best.Score, ok)
+	}
+	if _, ok := h.Best(game.ModeUltra); ok {
+		t.Error("found a best ultra without any ultra games")
+	}
+}
+
+func TestLoad_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), File)
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	h, err := Load(path)
+	if !errors.Is(err, store.ErrCorrupt) || h == nil || len(h.Games) != 0 {
+		t.Errorf("Load = %+v, %v, want an empty history and ErrCorrupt", h, err)
+	}
+}
+
+func TestReport(t *testing.T) {
+	var h History
+	for i := range 4 {
+		h.Add(Record{
+			Mode:      game.ModeSprint,
+			Completed: i > 0,
+			Lines:     40,
+			Pieces:    100,
+			Inputs:    400 - 20*i,
+			Time:      time.Duration(100-10*i) * time.Second,
+			Clears:    map[string]int{"tetris": 2},
+		})
+	}
+
+	var buf bytes.Buffer
+	Report(&buf, &h, 20)
+	out := buf.String()
+	for _, want := range []string{
+		"SPRINT: last 4 games",
+		"1:20.00", // Average time of the three completed sprints
+		"1:10.00", // Best time
+		"clears: tetris 8",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "MARATHON") {
+		t.Errorf("report lists a mode without games:\n%s", out)
+	}
+	kpp := lineOf(out, "KPP")
+	if !strings.Contains(kpp, "better") {
+		t.Errorf("falling KPP is not reported as better: %q", kpp)
+	}
+}
+
+func lineOf(out, prefix string) string {
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), prefix) {
+			return line
+		}
+	}
+	return ""
+}
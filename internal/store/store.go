@@ -0,0 +1,88 @@
+// Package store keeps small JSON files such as records and settings under the
+// user's data directory, writing them atomically so a crash never leaves a
+// half-written file behind.
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrCorrupt is returned by Load when a file could not be decoded. The file is
+// moved aside to name.corrupt so that the next Save starts afresh.
+var ErrCorrupt = errors.New("store: corrupt file")
+
+// Dir returns termino's data directory, $XDG_DATA_HOME/termino or
+// ~/.local/share/termino.
+func Dir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "termino"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share", "termino"), nil
+}
+
+// Path returns the path of the named file in the data directory.
+func Path(name string) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// Load decodes the JSON file at path into v. A missing file leaves v unchanged
+// and is not an error.
+func Load(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		if rerr := os.Rename(path, path+".corrupt"); rerr != nil {
+			return rerr
+		}
+		return fmt.Errorf("%w %s: %v", ErrCorrupt, path, err)
+	}
+	return nil
+}
+
+// Save encodes v as JSON and replaces the file at path with it atomically.
+func Save(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
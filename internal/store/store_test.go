@@ -0,0 +1,68 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type record struct {
+	Name  string
+	Score int
+}
+
+func TestSaveLoad_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "records.json")
+	want := []record{{"ada", 1200}, {"bob", 800}}
+	if err := Save(path, want); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	var got []record
+	if err := Load(path, &got); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("expected only the saved file, found %d entries", len(entries))
+	}
+}
+
+func TestLoad_Missing(t *testing.T) {
+	got := record{Name: "default"}
+	if err := Load(filepath.Join(t.TempDir(), "none.json"), &got); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if got.Name != "default" {
+		t.Errorf("missing file changed the value to %v", got)
+	}
+}
+
+func TestLoad_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.json")
+	os.WriteFile(path, []byte(`{"Name": "ada", "Sco`), 0o644)
+
+	var got record
+	if err := Load(path, &got); !errors.Is(err, ErrCorrupt) {
+		t.Fatalf("Load error = %v, want ErrCorrupt", err)
+	}
+	if _, err := os.Stat(path + ".corrupt"); err != nil {
+		t.Errorf("corrupt file not moved aside: %v", err)
+	}
+	if err := Load(path, &got); err != nil {
+		t.Errorf("Load after recovery failed: %v", err)
+	}
+}
+
+func TestDir_XDG(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", "/data")
+	dir, err := Dir()
+	if err != nil || dir != filepath.Join("/data", "termino") {
+		t.Errorf("Dir() = %q, %v", dir, err)
+	}
+}
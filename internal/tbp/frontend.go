@@ -0,0 +1,339 @@
+package tbp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"slices"
+	"time"
+
+	"termino/internal/game"
+	"termino/internal/tetromino"
+)
+
+// Options configure how long the frontend waits for a bot.
+type Options struct {
+	StartTimeout   time.Duration // For the info and ready messages
+	SuggestTimeout time.Duration // For each suggestion
+	Stderr         io.Writer     // Receives the bot's stderr; discarded when nil
+}
+
+// DefaultOptions suit bots that think in real time.
+var DefaultOptions = Options{
+	StartTimeout:   10 * time.Second,
+	SuggestTimeout: 2 * time.Second,
+}
+
+// ErrTimeout is returned when the bot does not answer in time.
+var ErrTimeout = errors.New("tbp: bot did not respond in time")
+
+// Frontend runs an external bot process and implements game.Advisor by
+// relaying the game to it. Once the bot misbehaves the frontend stops
+// suggesting moves and Err reports why.
+type Frontend struct {
+	Name    string // From the bot's info message
+	Version string
+	Author  string
+
+	opts  Options
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	lines chan []byte
+	done  chan struct{} // Closed once the bot's stdout is exhausted
+	err   error
+
+	// The bot's view of the game, used to send play and new_piece messages
+	// while it matches and to restart the bot with a fresh start otherwise.
+	started bool
+	board   game.Board
+	queue   []string
+	hold    string
+	pieces  int
+	pending *pendingMove
+}
+
+// pendingMove is a suggestion handed to the game but not yet confirmed by play.
+type pendingMove struct {
+	move      Move
+	hold      bool
+	piece     tetromino.Tetromino
+	placement game.Placement
+}
+
+// Start launches the bot and performs the info, rules and ready handshake.
+func Start(opts Options, name string, args ...string) (*Frontend, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stderr = opts.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("tbp: start %s: %w", name, err)
+	}
+
+	f := &Frontend{
+		opts:  opts,
+		cmd:   cmd,
+		stdin: stdin,
+		lines: make(chan []byte, 16),
+		done:  make(chan struct{}),
+	}
+	go f.readLoop(stdout)
+
+	info, err := f.recv(opts.StartTimeout)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if info.Type != "info" {
+		f.Close()
+		return nil, fmt.Errorf("tbp: expected info, got %q", info.Type)
+	}
+	f.Name, f.Version, f.Author = info.Name, info.Version, info.Author
+
+	if err := f.send(rulesMsg{Type: "rules", Randomizer: "seven_bag"}); err != nil {
+		f.Close()
+		return nil, err
+	}
+	ready, err := f.recv(opts.StartTimeout)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if ready.Type != "ready" {
+		f.Close()
+		return nil, fmt.Errorf("tbp: expected ready, got %q", ready.Type)
+	}
+	return f, nil
+}
+
+func (f *Frontend) readLoop(r io.Reader) {
+	defer close(f.done)
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for sc.Scan() {
+		line := slices.Clone(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		select {
+		case f.lines <- line:
+		case <-time.After(time.Minute):
+			// Nobody is reading any more.
+			return
+		}
+	}
+}
+
+// recv waits for the next message from the bot.
+func (f *Frontend) recv(timeout time.Duration) (botMsg, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	var line []byte
+	select {
+	case line = <-f.lines:
+	case <-f.done:
+		select {
+		case line = <-f.lines:
+		default:
+			return botMsg{}, errors.New("tbp: bot exited")
+		}
+	case <-timer.C:
+		return botMsg{}, ErrTimeout
+	}
+
+	var msg botMsg
+	if err := json.Unmarshal(line, &msg); err != nil {
+		return botMsg{}, fmt.Errorf("tbp: invalid message from bot: %w", err)
+	}
+	if msg.Type == "error" {
+		return botMsg{}, fmt.Errorf("tbp: bot error: %s", msg.Reason)
+	}
+	return msg, nil
+}
+
+func (f *Frontend) send(msg any) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if _, err := f.stdin.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("tbp: write to bot: %w", err)
+	}
+	return nil
+}
+
+// Err returns the error that stopped the frontend, if any.
+func (f *Frontend) Err() error {
+	return f.err
+}
+
+// Close asks the bot to quit and kills it if it does not exit promptly.
+func (f *Frontend) Close() error {
+	f.send(simpleMsg{Type: "quit"})
+	f.stdin.Close()
+
+	exited := make(chan error, 1)
+	go func() { exited <- f.cmd.Wait() }()
+	select {
+	case <-exited:
+	case <-time.After(time.Second):
+		f.cmd.Process.Kill()
+		<-exited
+	}
+	return nil
+}
+
+// Suggest asks the bot for a move and converts the first legal one it
+// proposes into a suggestion for the current piece.
+func (f *Frontend) Suggest(g *game.GameState) (game.Suggestion, bool) {
+	if f.err != nil || g.GameOver {
+		return game.Suggestion{}, false
+	}
+	s, err := f.suggest(g)
+	if err != nil {
+		f.err = err
+		return game.Suggestion{}, false
+	}
+	return s, true
+}
+
+func (f *Frontend) suggest(g *game.GameState) (game.Suggestion, error) {
+	if err := f.sync(g); err != nil {
+		return game.Suggestion{}, err
+	}
+	if err := f.send(simpleMsg{Type: "suggest"}); err != nil {
+		return game.Suggestion{}, err
+	}
+	msg, err := f.recv(f.opts.SuggestTimeout)
+	if err != nil {
+		return game.Suggestion{}, err
+	}
+	if msg.Type != "suggestion" {
+		return game.Suggestion{}, fmt.Errorf("tbp: expected suggestion, got %q", msg.Type)
+	}
+
+	for _, move := range msg.Moves {
+		pending, ok := f.resolve(g, move)
+		if !ok {
+			continue
+		}
+		f.pending = pending
+		return game.Suggestion{Hold: pending.hold, Placement: pending.placement}, nil
+	}
+	return game.Suggestion{}, errors.New("tbp: bot suggested no legal move")
+}
+
+// resolve finds the move among the placements the move generator can reach.
+func (f *Frontend) resolve(g *game.GameState, move Move) (*pendingMove, bool) {
+	piece, target, err := toPlacement(move.Location)
+	if err != nil {
+		return nil, false
+	}
+	target.Spin = move.Spin != "none" && move.Spin != ""
+
+	var placements []game.Placement
+	hold := false
+	switch {
+	case piece.Name == g.CurrentPiece.Name:
+		placements = g.Placements()
+	default:
+		held, x, y, ok := g.HoldPreview()
+		if !ok || held.Name != piece.Name {
+			return nil, false
+		}
+		hold = true
+		placements = game.GeneratePlacements(&g.Board, held, x, y, 0)
+	}
+
+	p, ok := game.FindPlacement(placements, &piece, target)
+	if !ok {
+		return nil, false
+	}
+	return &pendingMove{move: fromPlacement(&piece, p), hold: hold, piece: piece, placement: p}, true
+}
+
+// sync brings the bot up to date. If the game advanced by exactly the last
+// suggested move, the bot is told with play and new_piece messages; any other
+// difference (a first call, garbage, a human move) restarts it with start.
+func (f *Frontend) sync(g *game.GameState) error {
+	current := append([]string{g.CurrentPiece.Name}, names(g.NextQueue)...)
+	hold := ""
+	if g.HoldPiece != nil {
+		hold = g.HoldPiece.Name
+	}
+
+	if f.started && f.pending != nil && g.PiecesPlaced == f.pieces+1 {
+		board, queue, held := f.board, f.queue, f.hold
+		if f.pending.hold {
+			if held == "" {
+				held, queue = queue[0], queue[1:]
+			} else {
+				held = queue[0]
+			}
+		}
+		queue = queue[1:]
+		game.PlaceOnBoard(&board, &f.pending.piece, f.pending.placement)
+
+		if board == g.Board && held == hold && len(queue) <= len(current) && slices.Equal(queue, current[:len(queue)]) {
+			if err := f.send(playMsg{Type: "play", Move: f.pending.move}); err != nil {
+				return err
+			}
+			for _, p := range current[len(queue):] {
+				if err := f.send(newPieceMsg{Type: "new_piece", Piece: p}); err != nil {
+					return err
+				}
+			}
+			f.board, f.queue, f.hold = g.Board, current, hold
+			f.pieces = g.PiecesPlaced
+			f.pending = nil
+			return nil
+		}
+	}
+
+	if f.started && f.pending == nil && g.PiecesPlaced == f.pieces && f.board == g.Board &&
+		f.hold == hold && slices.Equal(f.queue, current) {
+		return nil
+	}
+
+	if f.started {
+		if err := f.send(simpleMsg{Type: "stop"}); err != nil {
+			return err
+		}
+	}
+	start := startMsg{
+		Type:       "start",
+		Queue:      current,
+		Combo:      g.Combo,
+		BackToBack: g.BackToBack,
+		Board:      boardCells(g),
+	}
+	if hold != "" {
+		start.Hold = &hold
+	}
+	if err := f.send(start); err != nil {
+		return err
+	}
+	f.started = true
+	f.board, f.queue, f.hold = g.Board, current, hold
+	f.pieces = g.PiecesPlaced
+	f.pending = nil
+	return nil
+}
+
+func names(pieces []tetromino.Tetromino) []string {
+	out := make([]string, len(pieces))
+	for i, p := range pieces {
+		out[i] = p.Name
+	}
+	return out
+}
@@ -0,0 +1,131 @@
+// Package tbp is a frontend for the Tetris Bot Protocol, which lets external
+// bots such as Cold Clear play termino over JSON messages on stdin and stdout.
+package tbp
+
+import (
+	"fmt"
+
+	"termino/internal/game"
+	"termino/internal/tetromino"
+	"termino/pkg/consts"
+)
+
+// Orientations in TBP order, matching termino's rotation numbers.
+var orientations = [4]string{"north", "east", "south", "west"}
+
+// Location is a piece placement by rotation centre; y counts up from the bottom row.
+type Location struct {
+	Type        string `json:"type"`
+	Orientation string `json:"orientation"`
+	X           int    `json:"x"`
+	Y           int    `json:"y"`
+}
+
+// Move is a placement together with the spin it scores as ("none", "mini" or "full").
+type Move struct {
+	Location Location `json:"location"`
+	Spin     string   `json:"spin"`
+}
+
+// Frontend to bot messages.
+type (
+	rulesMsg struct {
+		Type       string `json:"type"`
+		Randomizer string `json:"randomizer"`
+	}
+
+	startMsg struct {
+		Type       string      `json:"type"`
+		Hold       *string     `json:"hold"`
+		Queue      []string    `json:"queue"`
+		Combo      int         `json:"combo"`
+		BackToBack bool        `json:"back_to_back"`
+		Board      [][]*string `json:"board"`
+	}
+
+	playMsg struct {
+		Type string `json:"type"`
+		Move Move   `json:"move"`
+	}
+
+	newPieceMsg struct {
+		Type  string `json:"type"`
+		Piece string `json:"piece"`
+	}
+
+	simpleMsg struct {
+		Type string `json:"type"`
+	}
+)
+
+// botMsg is any message sent by the bot; only the fields of its type are set.
+type botMsg struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Author  string `json:"author"`
+	Reason  string `json:"reason"`
+	Moves   []Move `json:"moves"`
+}
+
+// boardCells converts the board to TBP's rows of cells, bottom row first.
+// Blocks whose colour is not a piece's are sent as garbage ("G").
+func boardCells(g *game.GameState) [][]*string {
+	rows := make([][]*string, consts.BoardHeight)
+	for y := range rows {
+		row := consts.BoardHeight - 1 - y
+		rows[y] = make([]*string, consts.BoardWidth)
+		for x := range consts.BoardWidth {
+			if g.Board[row]&tetromino.Bitmask(1<<x) == 0 {
+				continue
+			}
+			cell := "G"
+			if name, ok := tetromino.FromColor(g.BoardColors[row][x]); ok {
+				cell = name
+			}
+			rows[y][x] = &cell
+		}
+	}
+	return rows
+}
+
+// toPlacement converts a TBP location to the termino piece and placement it describes.
+func toPlacement(loc Location) (tetromino.Tetromino, game.Placement, error) {
+	if !validPiece(loc.Type) {
+		return tetromino.Tetromino{}, game.Placement{}, fmt.Errorf("unknown piece %q", loc.Type)
+	}
+	rot := -1
+	for i, o := range orientations {
+		if o == loc.Orientation {
+			rot = i
+		}
+	}
+	if rot < 0 {
+		return tetromino.Tetromino{}, game.Placement{}, fmt.Errorf("unknown orientation %q", loc.Orientation)
+	}
+
+	piece := tetromino.NewTetromino(loc.Type)
+	x, y := game.CenterToMask(&piece, rot, loc.X, loc.Y)
+	return piece, game.Placement{X: x, Y: y, Rotation: rot}, nil
+}
+
+// fromPlacement converts a termino placement to a TBP move.
+func fromPlacement(piece *tetromino.Tetromino, p game.Placement) Move {
+	cx, cy := game.MaskToCenter(piece, p.Rotation, p.X, p.Y)
+	spin := "none"
+	if p.Spin && piece.Name == "T" {
+		spin = "full"
+	}
+	return Move{
+		Location: Location{Type: piece.Name, Orientation: orientations[p.Rotation], X: cx, Y: cy},
+		Spin:     spin,
+	}
+}
+
+func validPiece(name string) bool {
+	switch name {
+	case "I", "J", "L", "O", "S", "T", "Z":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,190 @@
+package tbp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"termino/internal/game"
+	"termino/pkg/consts"
+)
+
+// The test binary doubles as a fake bot when TBP_FAKE_BOT names a behaviour.
+func TestMain(m *testing.M) {
+	if mode := os.Getenv("TBP_FAKE_BOT"); mode != "" {
+		fakeBot(mode)
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// fakeBot answers suggest with the current piece in the spawn orientation on
+// the floor: at the left wall after a start, then to the right after one play,
+// and floating (illegally) after more.
+func fakeBot(mode string) {
+	out := json.NewEncoder(os.Stdout)
+	out.Encode(map[string]any{"type": "info", "name": "fake", "version": "1", "author": "test", "features": []string{}})
+
+	var queue []string
+	played := 0
+	sc := bufio.NewScanner(os.Stdin)
+	sc.Buffer(nil, 1<<20)
+	for sc.Scan() {
+		var msg struct {
+			Type  string   `json:"type"`
+			Queue []string `json:"queue"`
+			Piece string   `json:"piece"`
+			Move  Move     `json:"move"`
+		}
+		json.Unmarshal(sc.Bytes(), &msg)
+		switch msg.Type {
+		case "rules":
+			out.Encode(map[string]any{"type": "ready"})
+		case "start":
+			queue = msg.Queue
+			played = 0
+		case "new_piece":
+			queue = append(queue, msg.Piece)
+		case "play":
+			queue = queue[1:]
+			played++
+		case "suggest":
+			switch mode {
+			case "slow":
+				time.Sleep(time.Second)
+			case "garbled":
+				fmt.Println("{not json")
+				continue
+			case "illegal":
+				out.Encode(map[string]any{"type": "suggestion", "moves": []Move{{
+					Location: Location{Type: queue[0], Orientation: "north", X: 4, Y: 30},
+					Spin:     "none",
+				}}})
+				continue
+			}
+			x, y := 5*played+1, 0
+			if queue[0] == "O" {
+				x--
+			}
+			if played > 1 {
+				y = 10
+			}
+			out.Encode(map[string]any{"type": "suggestion", "moves": []Move{
+				{Location: Location{Type: queue[0], Orientation: "north", X: -5, Y: 0}, Spin: "none"},
+				{Location: Location{Type: queue[0], Orientation: "north", X: x, Y: y}, Spin: "none"},
+			}})
+		case "quit":
+			return
+		}
+	}
+}
+
+func startFake(t *testing.T, mode string, timeout time.Duration) *Frontend {
+	t.Helper()
+	t.Setenv("TBP_FAKE_BOT", mode)
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := DefaultOptions
+	opts.SuggestTimeout = timeout
+	f, err := Start(opts, exe)
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func TestFrontend_PlaysSuggestions(t *testing.T) {
+	f := startFake(t, "good", 2*time.Second)
+	if f.Name != "fake" {
+		t.Errorf("Name = %q, want fake", f.Name)
+	}
+
+	g := game.NewGameStateWithSeed(1)
+	play := func(i int) {
+		t.Helper()
+		s, ok := f.Suggest(&g)
+		if !ok {
+			t.Fatalf("piece %d: no suggestion: %v", i, f.Err())
+		}
+		if s.Hold || s.Placement.Rotation != 0 {
+			t.Fatalf("piece %d: suggestion %+v, want spawn rotation without hold", i, s)
+		}
+		for _, a := range s.Inputs() {
+			g.Apply(a)
+		}
+		if g.PiecesPlaced != i+1 {
+			t.Fatalf("piece %d: not placed", i)
+		}
+	}
+
+	// The second piece is only legal if the bot was told the first was played.
+	play(0)
+	play(1)
+	if g.Board[consts.BoardHeight-1]&(1|1<<5) != 1|1<<5 {
+		t.Fatalf("bottom row %010b, want pieces at columns 0 and 5", g.Board[consts.BoardHeight-1])
+	}
+
+	// After an outside change the bot must be restarted rather than sent play.
+	for y := range g.Board {
+		g.Board[y] = 0
+	}
+	play(2)
+	if f.Err() != nil {
+		t.Errorf("Err = %v", f.Err())
+	}
+}
+
+func TestFrontend_Misbehaving(t *testing.T) {
+	tests := []struct {
+		mode string
+		want string
+	}{
+		{"slow", "timeout"},
+		{"garbled", "invalid message"},
+		{"illegal", "no legal move"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			f := startFake(t, tt.mode, 200*time.Millisecond)
+			g := game.NewGameStateWithSeed(1)
+			if _, ok := f.Suggest(&g); ok {
+				t.Fatal("Suggest succeeded, want failure")
+			}
+			err := f.Err()
+			if err == nil {
+				t.Fatal("Err = nil")
+			}
+			if tt.mode == "slow" && !errors.Is(err, ErrTimeout) {
+				t.Errorf("Err = %v, want ErrTimeout", err)
+			}
+			if tt.mode != "slow" && !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Err = %v, want it to mention %q", err, tt.want)
+			}
+			if _, ok := f.Suggest(&g); ok {
+				t.Error("Suggest succeeded after an error")
+			}
+		})
+	}
+}
+
+func TestLocation_RoundTrip(t *testing.T) {
+	g := game.NewGameStateWithSeed(3)
+	for _, p := range g.Placements() {
+		move := fromPlacement(&g.CurrentPiece, p)
+		piece, back, err := toPlacement(move.Location)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if piece.Name != g.CurrentPiece.Name || back.X != p.X || back.Y != p.Y || back.Rotation != p.Rotation {
+			t.Errorf("%+v -> %+v -> %+v", p, move.Location, back)
+		}
+	}
+}
@@ -140,3 +140,56 @@ func NewTetromino(name string) Tetromino {
 
 	return t
 }
+
+// spawnMinos holds each piece's cells in spawn orientation relative to its SRS
+// rotation centre, with y pointing up.
+var spawnMinos = map[string][4][2]int{
+	"I": {{0, 0}, {-1, 0}, {1, 0}, {2, 0}},
+	"J": {{0, 0}, {-1, 0}, {1, 0}, {-1, 1}},
+	"L": {{0, 0}, {-1, 0}, {1, 0}, {1, 1}},
+	"O": {{0, 0}, {1, 0}, {0, 1}, {1, 1}},
+	"S": {{0, 0}, {-1, 0}, {0, 1}, {1, 1}},
+	"T": {{0, 0}, {-1, 0}, {1, 0}, {0, 1}},
+	"Z": {{0, 0}, {1, 0}, {0, 1}, {-1, 1}},
+}
+
+// Minos returns the cells of the named piece in rotation rot (0 spawn, 1 right,
+// 2 reverse, 3 left) relative to its rotation centre, with y pointing up.
+// This is the convention used by fumen and the Tetris Bot Protocol.
+func Minos(name string, rot int) [4][2]int {
+	minos := spawnMinos[name]
+	for i, m := range minos {
+		x, y := m[0], m[1]
+		switch rot & 3 {
+		case 1:
+			x, y = y, -x
+		case 2:
+			x, y = -x, -y
+		case 3:
+			x, y = -y, x
+		}
+		minos[i] = [2]int{x, y}
+	}
+	return minos
+}
+
+// FromColor returns the name of the piece drawn with colour c.
+func FromColor(c lipgloss.Color) (string, bool) {
+	switch c {
+	case ColorI:
+		return "I", true
+	case ColorJ:
+		return "J", true
+	case ColorL:
+		return "L", true
+	case ColorO:
+		return "O", true
+	case ColorS:
+		return "S", true
+	case ColorT:
+		return "T", true
+	case ColorZ:
+		return "Z", true
+	}
+	return "", false
+}